@@ -39,10 +39,39 @@ type Config struct {
 	} `toml:"redis"`
 
 	SearchService struct {
-		Host string `toml:"host"`
-		Port int    `toml:"port"`
+		Backend string `toml:"backend"`
+		Host    string `toml:"host"`
+		Port    int    `toml:"port"`
 	} `toml:"search_service"`
 
+	Token struct {
+		Issuer string `toml:"issuer"`
+
+		ActiveKeyID string `toml:"active_kid"`
+
+		Keys []TokenKeyConfig `toml:"keys"`
+	} `toml:"token"`
+
+	TwoFactor struct {
+		SecretKey string `toml:"secret_key"`
+	} `toml:"two_factor"`
+
+	Mail struct {
+		From string `toml:"from"`
+
+		SpoolDir string `toml:"spool_dir"`
+
+		BaseURL string `toml:"base_url"`
+	} `toml:"mail"`
+
+	RateLimit struct {
+		Enabled bool `toml:"enabled"`
+
+		Default RateLimitRule `toml:"default"`
+
+		Rules []RateLimitRule `toml:"rules"`
+	} `toml:"rate_limit"`
+
 	Compress struct {
 		Level compress.Level `toml:"level"`
 	} `toml:"compress"`
@@ -52,6 +81,26 @@ type Config struct {
 	} `toml:"env"`
 }
 
+type TokenKeyConfig struct {
+	ID string `toml:"kid"`
+
+	Algorithm string `toml:"algorithm"`
+
+	Secret string `toml:"secret"`
+
+	PrivateKeyPath string `toml:"private_key_path"`
+
+	PublicKeyPath string `toml:"public_key_path"`
+}
+
+type RateLimitRule struct {
+	Path string `toml:"path"`
+
+	Limit int `toml:"limit"`
+
+	Window int `toml:"window"`
+}
+
 func NewConfig() (*Config, error) {
 
 	file, err := os.ReadFile("./configuration.toml")
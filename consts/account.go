@@ -0,0 +1,9 @@
+package consts
+
+const (
+	ACCOUNT_DELETION_GRACE_PERIOD = 14 * 24 * 60 * 60
+
+	ACCOUNT_DELETION_BATCH_SIZE = 20
+
+	ACCOUNT_EXPORT_FILENAME = "account-export.zip"
+)
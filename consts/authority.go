@@ -0,0 +1,9 @@
+package consts
+
+const (
+	AUTHORITY_USER uint64 = 0
+
+	AUTHORITY_MODERATOR uint64 = 1
+
+	AUTHORITY_ADMIN uint64 = 2
+)
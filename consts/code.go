@@ -12,4 +12,10 @@ const (
 	AUTH_ERROR serializers.ResponseCode = 3
 
 	NETWORK_ERROR serializers.ResponseCode = 4
+
+	FORBIDDEN_ERROR serializers.ResponseCode = 5
+
+	LOGIN_LOCKED_ERROR serializers.ResponseCode = 6
+
+	RATE_LIMIT_ERROR serializers.ResponseCode = 7
 )
@@ -0,0 +1,39 @@
+package consts
+
+const (
+	LOGIN_MAX_FAILURES_PER_USER = 5
+
+	LOGIN_MAX_FAILURES_PER_IP = 20
+
+	LOGIN_FAILURE_WINDOW = 15 * 60
+
+	LOGIN_FREE_ATTEMPTS = 2
+
+	LOGIN_MAX_DELAY = 30
+
+	LOGIN_LOCK_BASE_DURATION = 5 * 60
+
+	LOGIN_LOCK_MAX_DURATION = 24 * 60 * 60
+
+	LOGIN_LOCK_STRIKE_WINDOW = 24 * 60 * 60
+
+	REDIS_LOGIN_FAILURE = "LOGIN:FAILURE"
+
+	REDIS_LOGIN_LOCK = "LOGIN:LOCK"
+
+	REDIS_LOGIN_STRIKE = "LOGIN:STRIKE"
+
+	REDIS_LOGIN_DELAY = "LOGIN:DELAY"
+
+	LOGIN_ANOMALY_BATCH_SIZE = 500
+
+	LOGIN_ANOMALY_BURST_WINDOW = 10 * 60
+
+	LOGIN_ANOMALY_BURST_IP_COUNT = 3
+
+	LOGIN_ANOMALY_BURST_FAILURE_COUNT = 10
+
+	LOGIN_REASON_TWO_FACTOR_CHALLENGE = "two-factor challenge issued"
+
+	LOGIN_HISTORY_MAX_LENGTH = 50
+)
@@ -1,9 +1,14 @@
 package consts
 
 const (
-	MONGODB_DATABASE_NAME    = "complex_micro_blog"
-	POST_LIKE_COLLECTION     = "post_likes"
-	POST_FAVORITE_COLLECTION = "post_favourites"
-	COMMENT_RATE_COLLECTION  = "comment_rates"
-	FOLLOW_RECORD_COLLECTION = "follow_records"
+	MONGODB_DATABASE_NAME     = "complex_micro_blog"
+	POST_LIKE_COLLECTION      = "post_likes"
+	POST_FAVORITE_COLLECTION  = "post_favourites"
+	COMMENT_RATE_COLLECTION   = "comment_rates"
+	FOLLOW_RECORD_COLLECTION  = "follow_records"
+	POST_FORWARD_COLLECTION   = "post_forwards"
+	NOTIFICATION_COLLECTION   = "notifications"
+	FOLLOW_REQUEST_COLLECTION = "follow_requests"
+	BLOCK_RECORD_COLLECTION   = "block_records"
+	MUTE_RECORD_COLLECTION    = "mute_records"
 )
@@ -0,0 +1,7 @@
+package consts
+
+const (
+	NOTIFICATION_LIST_MAX_LENGTH = 20
+
+	NOTIFICATION_ACTOR_PREVIEW_LENGTH = 5
+)
@@ -0,0 +1,15 @@
+package consts
+
+const (
+	OUTBOX_BATCH_SIZE = 100
+
+	OUTBOX_LEASE_DURATION = 60
+
+	OUTBOX_RETRY_BASE_DELAY = 5
+
+	OUTBOX_RETRY_MAX_DELAY = 60 * 60
+
+	OUTBOX_MAX_ATTEMPTS = 20
+
+	OUTBOX_DEAD_LIST_LENGTH = 20
+)
@@ -0,0 +1,13 @@
+package consts
+
+const (
+	ARGON2_TIME = 3
+
+	ARGON2_MEMORY = 64 * 1024
+
+	ARGON2_THREADS = 2
+
+	ARGON2_KEY_LENGTH = 32
+
+	ARGON2_SALT_LENGTH = 16
+)
@@ -0,0 +1,5 @@
+package consts
+
+const (
+	REDIS_RATE_LIMIT = "RATE_LIMIT"
+)
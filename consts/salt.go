@@ -1,3 +0,0 @@
-package consts
-
-const SALT_LENGTH = 12
@@ -0,0 +1,37 @@
+package consts
+
+const (
+	SEARCH_BACKEND_LOCAL = "local"
+
+	SEARCH_BACKEND_GRPC = "grpc"
+
+	SEARCH_INDEX_BATCH_SIZE = 500
+
+	SEARCH_DEFAULT_LENGTH = 20
+
+	SEARCH_MAX_LENGTH = 100
+
+	SEARCH_USER_DEFAULT_LENGTH = 10
+
+	SEARCH_USER_MAX_LENGTH = 20
+
+	SEARCH_HIGHLIGHT_CONTEXT = 20
+
+	SEARCH_HIGHLIGHT_LENGTH = 120
+
+	SEARCH_HIGHLIGHT_PRE_TAG = "<em>"
+
+	SEARCH_HIGHLIGHT_POST_TAG = "</em>"
+
+	SEARCH_REBUILD_BATCH_SIZE = 200
+
+	SEARCH_REBUILD_LOCK_DURATION = 60
+
+	SEARCH_VERIFY_DEFAULT_SAMPLE = 100
+
+	SEARCH_VERIFY_MAX_SAMPLE = 1000
+
+	REDIS_SEARCH_REBUILD_PROGRESS = "SEARCH:REBUILD:PROGRESS"
+
+	REDIS_SEARCH_REBUILD_LOCK = "SEARCH:REBUILD:LOCK"
+)
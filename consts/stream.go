@@ -0,0 +1,13 @@
+package consts
+
+const (
+	REDIS_USER_STREAM_CHANNEL = "STREAM:USER"
+
+	REDIS_POST_STREAM_CHANNEL = "STREAM:POST"
+
+	STREAM_HEARTBEAT_INTERVAL = 25
+
+	STREAM_TOKEN_CHECK_INTERVAL = 30
+
+	STREAM_MAX_POST_SUBSCRIPTIONS = 50
+)
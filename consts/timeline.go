@@ -0,0 +1,15 @@
+package consts
+
+const (
+	TIMELINE_MAX_LENGTH = 800
+
+	TIMELINE_FANOUT_THRESHOLD = 5000
+
+	TIMELINE_FANOUT_BATCH_SIZE = 500
+
+	TIMELINE_EXPIRE_TIME = 7 * 24 * 60 * 60
+
+	REDIS_USER_TIMELINE = "USER:TIMELINE"
+
+	REDIS_TIMELINE_CELEBRITY_SET = "TIMELINE:CELEBRITIES"
+)
@@ -1,13 +1,21 @@
 package consts
 
 const (
-	TOKEN_EXPIRE_DURATION = 7 * 24 * 60 * 60
+	TOKEN_EXPIRE_DURATION = 15 * 60
 
-	TOKEN_SECRET = "SAMPLE_BLOG_BACKEND_SECRET"
+	REFRESH_TOKEN_EXPIRE_DURATION = 30 * 24 * 60 * 60
+
+	REFRESH_TOKEN_SECRET_LENGTH = 40
 
 	TOKEN_ISSUER = "org.mehak.blog"
 
 	MAX_TOKENS_PER_USER = 5
 
-	REDIS_AVAILABLE_USER_TOKEN_LIST = "USER:TOKENS"
+	REDIS_USER_TOKEN = "USER:TOKEN"
+
+	REDIS_USER_TOKEN_INDEX = "USER:TOKEN_INDEX"
+
+	REDIS_USER_SESSION = "USER:SESSION"
+
+	REDIS_USER_SESSION_INDEX = "USER:SESSIONS"
 )
@@ -0,0 +1,35 @@
+package consts
+
+import "time"
+
+const (
+	TRENDING_WINDOW_HOUR = "1h"
+
+	TRENDING_WINDOW_DAY = "24h"
+
+	TRENDING_WINDOW_WEEK = "7d"
+
+	TRENDING_DEFAULT_WINDOW = TRENDING_WINDOW_DAY
+
+	TRENDING_LIST_MAX_LENGTH = 100
+
+	TRENDING_LIKE_WEIGHT = 1.0
+
+	TRENDING_FAVOURITE_WEIGHT = 2.0
+
+	TRENDING_COMMENT_WEIGHT = 3.0
+
+	TRENDING_HALF_LIFE_RATIO = 4
+
+	TRENDING_EXPIRE_TIME = 30 * time.Minute
+
+	REDIS_TRENDING_POST = "TRENDING:POST"
+
+	REDIS_TRENDING_TAG = "TRENDING:TAG"
+)
+
+var TRENDING_WINDOWS = map[string]time.Duration{
+	TRENDING_WINDOW_HOUR: time.Hour,
+	TRENDING_WINDOW_DAY:  24 * time.Hour,
+	TRENDING_WINDOW_WEEK: 7 * 24 * time.Hour,
+}
@@ -0,0 +1,31 @@
+package consts
+
+const (
+	TOTP_ISSUER = "MehakBlog"
+
+	TOTP_SECRET_SIZE = 20
+
+	SECRET_KEY_LENGTH = 32
+
+	TOTP_PERIOD = 30
+
+	TOTP_DIGITS = 6
+
+	TOTP_SKEW = 1
+
+	TOTP_ENROLL_EXPIRE_TIME = 10 * 60
+
+	RECOVERY_CODE_COUNT = 10
+
+	LOGIN_CHALLENGE_TOKEN_LENGTH = 40
+
+	LOGIN_CHALLENGE_EXPIRE_TIME = 5 * 60
+
+	LOGIN_CHALLENGE_MAX_ATTEMPTS = 5
+
+	REDIS_TOTP_ENROLL = "TOTP:ENROLL"
+
+	REDIS_TOTP_LAST_COUNTER = "TOTP:LAST_COUNTER"
+
+	REDIS_LOGIN_CHALLENGE = "LOGIN:CHALLENGE"
+)
@@ -0,0 +1,21 @@
+package consts
+
+const (
+	VERIFICATION_TOKEN_LENGTH = 40
+
+	EMAIL_VERIFY_TOKEN_EXPIRE_TIME = 24 * 60 * 60
+
+	PASSWORD_RESET_TOKEN_EXPIRE_TIME = 30 * 60
+
+	ACCOUNT_UNLOCK_TOKEN_EXPIRE_TIME = 30 * 60
+
+	REDIS_EMAIL_VERIFY_TOKEN = "EMAIL:VERIFY"
+
+	REDIS_PASSWORD_RESET_TOKEN = "PASSWORD:RESET"
+
+	REDIS_PASSWORD_RESET_USER = "PASSWORD:RESET_USER"
+
+	REDIS_ACCOUNT_UNLOCK_TOKEN = "ACCOUNT:UNLOCK"
+
+	REDIS_ACCOUNT_UNLOCK_USER = "ACCOUNT:UNLOCK_USER"
+)
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/services"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type AccountController struct {
+	accountService *services.AccountService
+}
+
+func (factory *Factory) NewAccountController() *AccountController {
+	return &AccountController{
+		accountService: factory.serviceFactory.NewAccountService(),
+	}
+}
+
+func (controller *AccountController) NewExportHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		data, err := controller.accountService.ExportAccount(claims.UID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		ctx.Set(fiber.HeaderContentType, "application/zip")
+		ctx.Attachment(consts.ACCOUNT_EXPORT_FILENAME)
+		return ctx.Status(200).Send(data)
+	}
+}
+
+func (controller *AccountController) NewDeletionStatusHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		accountDeletion, err := controller.accountService.GetAccountDeletion(claims.UID)
+		if errors.Is(err, services.ErrAccountDeletionNotScheduled) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewAccountDeletionResponse(accountDeletion)),
+		)
+	}
+}
+
+func (controller *AccountController) NewScheduleDeletionHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		reqBody := new(types.AccountDeletionBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.Password == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "password is required"),
+			)
+		}
+
+		accountDeletion, err := controller.accountService.ScheduleAccountDeletion(claims.UID, reqBody.Password)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.AUTH_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewAccountDeletionResponse(accountDeletion)),
+		)
+	}
+}
+
+func (controller *AccountController) NewCancelDeletionHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		err := controller.accountService.CancelAccountDeletion(claims.UID)
+		if errors.Is(err, services.ErrAccountDeletionNotScheduled) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed"),
+		)
+	}
+}
@@ -43,6 +43,11 @@ func (controller *CommentController) NewCreateCommentHandler(postStore *stores.P
 		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
 
 		commentID, err := controller.commentService.CreateComment(claims.UID, *reqBody.PostID, reqBody.Content, postStore, userStore)
+		if errors.Is(err, services.ErrBlocked) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
 		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
@@ -72,7 +77,14 @@ func (controller *CommentController) NewUpdateCommentHandler() fiber.Handler {
 			)
 		}
 
-		err = controller.commentService.UpdateComment(*reqBody.CommentID, reqBody.Content)
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		err = controller.commentService.UpdateComment(claims.UID, *reqBody.CommentID, reqBody.Content)
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
 		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
@@ -101,7 +113,15 @@ func (controller *CommentController) DeleteCommentHandler() fiber.Handler {
 			)
 		}
 
-		if err := controller.commentService.DeleteComment(*reqBody.CommentID); err != nil {
+		claims := c.Locals("claims").(*types.BearerTokenClaims)
+
+		err := controller.commentService.DeleteComment(claims.UID, *reqBody.CommentID)
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return c.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
 			return c.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
 			)
@@ -128,7 +148,17 @@ func (controller *CommentController) NewCommentListHandler() fiber.Handler {
 			)
 		}
 
-		comments, err := controller.commentService.GetCommentList(postIDUint)
+		var viewerUID uint64
+		if claims, ok := c.Locals("claims").(*types.BearerTokenClaims); ok {
+			viewerUID = claims.UID
+		}
+
+		comments, err := controller.commentService.GetCommentList(postIDUint, viewerUID)
+		if errors.Is(err, services.ErrPrivateAccount) {
+			return c.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
 		if err != nil {
 			return c.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
@@ -170,8 +200,15 @@ func (controller *CommentController) NewCommentDetailHandler() fiber.Handler {
 			)
 		}
 
+		entities, err := controller.commentService.GetCommentEntities(commentID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
 		return ctx.Status(200).JSON(
-			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewCommentDetailResponse(comment, likeCount)),
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewCommentDetailResponse(comment, likeCount, entities)),
 		)
 	}
 }
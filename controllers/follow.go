@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -35,11 +36,15 @@ func (controller *FollowController) NewCreateFollowHandler() fiber.Handler {
 		}
 		followedID := body.UserID
 
-		if err := controller.followService.FollowUser(claims.UID, followedID); err != nil {
+		pending, err := controller.followService.FollowUser(claims.UID, followedID)
+		if errors.Is(err, services.ErrBlocked) {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()))
+		}
+		if err != nil {
 			return ctx.Status(200).JSON(serializers.NewResponse(consts.SERVER_ERROR, err.Error()))
 		}
 
-		return ctx.JSON(serializers.NewResponse(consts.SUCCESS, "succeed"))
+		return ctx.JSON(serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewFollowResultResponse(pending)))
 	}
 }
 
@@ -176,3 +181,70 @@ func (controller *FollowController) NewFollowerCountHandler() fiber.Handler {
 		)
 	}
 }
+
+func (controller *FollowController) NewFollowRequestListHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		followRequests, err := controller.followService.GetFollowRequestList(claims.UID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewFollowRequestListResponse(followRequests)),
+		)
+	}
+}
+
+func (controller *FollowController) NewApproveFollowRequestHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		body := struct {
+			UserID uint64 `json:"user_id" form:"user_id"`
+		}{}
+		err := ctx.BodyParser(&body)
+		if err != nil {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "user_id is required"))
+		}
+
+		err = controller.followService.ApproveFollowRequest(claims.UID, body.UserID)
+		if errors.Is(err, services.ErrFollowRequestNotFound) {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()))
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.SERVER_ERROR, err.Error()))
+		}
+
+		return ctx.JSON(serializers.NewResponse(consts.SUCCESS, "succeed"))
+	}
+}
+
+func (controller *FollowController) NewRejectFollowRequestHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		body := struct {
+			UserID uint64 `json:"user_id" form:"user_id"`
+		}{}
+		err := ctx.BodyParser(&body)
+		if err != nil {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "user_id is required"))
+		}
+
+		err = controller.followService.RejectFollowRequest(claims.UID, body.UserID)
+		if errors.Is(err, services.ErrFollowRequestNotFound) {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()))
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.SERVER_ERROR, err.Error()))
+		}
+
+		return ctx.JSON(serializers.NewResponse(consts.SUCCESS, "succeed"))
+	}
+}
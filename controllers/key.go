@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/services"
+)
+
+type KeyController struct {
+	keyService *services.KeyService
+}
+
+func (factory *Factory) NewKeyController() *KeyController {
+	return &KeyController{
+		keyService: factory.serviceFactory.NewKeyService(),
+	}
+}
+
+func (controller *KeyController) NewJWKSHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+		return ctx.Status(200).JSON(controller.keyService.GetJWKS())
+	}
+}
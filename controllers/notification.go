@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/services"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type NotificationController struct {
+	notificationService *services.NotificationService
+}
+
+func (factory *Factory) NewNotificationController() *NotificationController {
+	return &NotificationController{
+		notificationService: factory.serviceFactory.NewNotificationService(),
+	}
+}
+
+func (controller *NotificationController) NewNotificationListHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		length := ctx.Query("len")
+		from := ctx.Query("from")
+		if length != "" {
+			_, err := strconv.ParseUint(length, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid length"),
+				)
+			}
+		}
+
+		notifications, err := controller.notificationService.GetNotificationList(claims.UID, length, from)
+		if errors.Is(err, services.ErrNotificationCursorInvalid) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(
+				consts.SUCCESS,
+				"succeed",
+				serializers.NewNotificationListResponse(notifications, consts.NOTIFICATION_ACTOR_PREVIEW_LENGTH),
+			),
+		)
+	}
+}
+
+func (controller *NotificationController) NewUnreadCountHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		count, err := controller.notificationService.GetUnreadCount(claims.UID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewUnreadCountResponse(count)),
+		)
+	}
+}
+
+func (controller *NotificationController) NewReadNotificationHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		reqBody := new(types.NotificationReadBody)
+		if err := ctx.BodyParser(reqBody); err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.NotificationID == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "notification id is required"),
+			)
+		}
+
+		err := controller.notificationService.MarkNotificationRead(claims.UID, reqBody.NotificationID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed"),
+		)
+	}
+}
+
+func (controller *NotificationController) NewReadAllNotificationsHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		err := controller.notificationService.MarkAllNotificationsRead(claims.UID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed"),
+		)
+	}
+}
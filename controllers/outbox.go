@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/services"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type OutboxController struct {
+	outboxService *services.OutboxService
+}
+
+func (factory *Factory) NewOutboxController() *OutboxController {
+	return &OutboxController{
+		outboxService: factory.serviceFactory.NewOutboxService(),
+	}
+}
+
+func (controller *OutboxController) NewOutboxLagHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		pending, retrying, dead, oldest, err := controller.outboxService.GetOutboxLag()
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		deadEvents, err := controller.outboxService.GetDeadOutboxEvents()
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewOutboxLagResponse(pending, retrying, dead, oldest, deadEvents, time.Now())),
+		)
+	}
+}
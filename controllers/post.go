@@ -8,7 +8,6 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/mehakhanaa/complex-micro-blog/consts"
-	search "github.com/mehakhanaa/complex-micro-blog/proto"
 	"github.com/mehakhanaa/complex-micro-blog/services"
 	"github.com/mehakhanaa/complex-micro-blog/stores"
 	"github.com/mehakhanaa/complex-micro-blog/types"
@@ -20,9 +19,9 @@ type PostController struct {
 	postService *services.PostService
 }
 
-func (factory *Factory) NewPostController(searchServiceClient search.SearchEngineClient) *PostController {
+func (factory *Factory) NewPostController() *PostController {
 	return &PostController{
-		postService: factory.serviceFactory.NewPostService(searchServiceClient),
+		postService: factory.serviceFactory.NewPostService(),
 	}
 }
 
@@ -33,7 +32,7 @@ func (controller *PostController) NewPostListHandler(userStore *stores.UserStore
 		uid := ctx.Query("uid")
 		length := ctx.Query("len")
 		from := ctx.Query("from")
-		if reqType == "user" || reqType == "liked" || reqType == "favourited" {
+		if reqType == "user" || reqType == "liked" || reqType == "favourited" || reqType == "reposted" || reqType == "mentioned" {
 			_, err := strconv.ParseUint(uid, 10, 64)
 			if err != nil {
 				return ctx.Status(200).JSON(
@@ -58,26 +57,49 @@ func (controller *PostController) NewPostListHandler(userStore *stores.UserStore
 			}
 		}
 
+		var viewerUID uint64
+		if claims, ok := ctx.Locals("claims").(*types.BearerTokenClaims); ok {
+			viewerUID = claims.UID
+		}
+
 		var (
 			posts []int64
 			err   error
 		)
 		switch reqType {
 		case "":
-			posts, err = controller.postService.GetPostList("all", "", length, from, userStore)
+			posts, err = controller.postService.GetPostList("all", "", length, from, viewerUID, userStore)
 		case "all":
-			posts, err = controller.postService.GetPostList("all", "", length, from, userStore)
+			posts, err = controller.postService.GetPostList("all", "", length, from, viewerUID, userStore)
 		case "user":
-			posts, err = controller.postService.GetPostList("user", uid, length, from, userStore)
+			posts, err = controller.postService.GetPostList("user", uid, length, from, viewerUID, userStore)
 		case "liked":
-			posts, err = controller.postService.GetPostList("liked", uid, length, from, userStore)
+			posts, err = controller.postService.GetPostList("liked", uid, length, from, viewerUID, userStore)
 			posts = functools.Reverse(posts)
 		case "favourited":
-			posts, err = controller.postService.GetPostList("favourited", uid, length, from, userStore)
+			posts, err = controller.postService.GetPostList("favourited", uid, length, from, viewerUID, userStore)
+			posts = functools.Reverse(posts)
+		case "reposted":
+			posts, err = controller.postService.GetPostList("reposted", uid, length, from, viewerUID, userStore)
 			posts = functools.Reverse(posts)
+		case "mentioned":
+			posts, err = controller.postService.GetPostList("mentioned", uid, length, from, viewerUID, userStore)
+		case "following":
+			claims, ok := ctx.Locals("claims").(*types.BearerTokenClaims)
+			if !ok {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.AUTH_ERROR, "bearer token is required"),
+				)
+			}
+			posts, err = controller.postService.GetPostList("following", strconv.FormatUint(claims.UID, 10), length, from, viewerUID, userStore)
 		default:
 			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "invalid type"))
 		}
+		if errors.Is(err, services.ErrPrivateAccount) || errors.Is(err, services.ErrBlocked) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
 		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
@@ -107,7 +129,18 @@ func (controller *PostController) NewPostDetailHandler() fiber.Handler {
 			)
 		}
 
-		post, likeCount, favouriteCount, err := controller.postService.GetPostInfo(postID)
+		var viewerUID uint64
+		if claims, ok := ctx.Locals("claims").(*types.BearerTokenClaims); ok {
+			viewerUID = claims.UID
+		}
+
+		post, likeCount, favouriteCount, forwardCount, err := controller.postService.GetPostInfo(postID, viewerUID)
+
+		if errors.Is(err, services.ErrPrivateAccount) || errors.Is(err, services.ErrBlocked) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
 
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ctx.Status(200).JSON(
@@ -121,8 +154,22 @@ func (controller *PostController) NewPostDetailHandler() fiber.Handler {
 			)
 		}
 
+		parentPost, parentHidden, err := controller.postService.GetParentPost(post, viewerUID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		entities, err := controller.postService.GetPostEntities(postID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
 		return ctx.Status(200).JSON(
-			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewPostDetailResponse(post, likeCount, favouriteCount)),
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewPostDetailResponse(post, parentPost, parentHidden, likeCount, favouriteCount, forwardCount, entities)),
 		)
 	}
 }
@@ -168,6 +215,99 @@ func (controller *PostController) NewCreatePostHandler() fiber.Handler {
 	}
 }
 
+func (controller *PostController) NewUpdatePostHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		reqBody := types.PostUpdateBody{}
+		err := ctx.BodyParser(&reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.PostID == nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "post id is required"),
+			)
+		}
+		if reqBody.Title == "" || reqBody.Content == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "post title or post content is required"),
+			)
+		}
+
+		err = controller.postService.UpdatePost(claims.UID, *reqBody.PostID, reqBody.Title, reqBody.Content)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "post does not exist"),
+			)
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed"),
+		)
+	}
+}
+
+func (controller *PostController) NewQuotePostHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		reqBody := types.PostQuoteBody{}
+		err := ctx.BodyParser(&reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.PostID == nil || reqBody.Content == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "post id or post content is required"),
+			)
+		}
+		if len(reqBody.Images) > 9 {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "post images count exceeds the limit"),
+			)
+		}
+
+		postInfo, err := controller.postService.QuotePost(claims.UID, ctx.IP(), *reqBody.PostID, reqBody.PostCreateBody)
+		if errors.Is(err, services.ErrPrivateAccount) || errors.Is(err, services.ErrBlocked) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(
+				consts.SUCCESS,
+				"post created successfully",
+				serializers.NewCreatePostResponse(postInfo),
+			),
+		)
+	}
+}
+
 func (controller *PostController) NewPostUserStatusHandler() fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 
@@ -184,7 +324,7 @@ func (controller *PostController) NewPostUserStatusHandler() fiber.Handler {
 			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "post id must be a number"))
 		}
 
-		isLiked, isFavourited, err := controller.postService.GetPostUserStatus(int64(claims.UID), int64(postIDUint))
+		isLiked, isFavourited, isReposted, err := controller.postService.GetPostUserStatus(int64(claims.UID), int64(postIDUint))
 		if err != nil {
 			return ctx.Status(200).JSON(serializers.NewResponse(consts.SERVER_ERROR, err.Error()))
 		}
@@ -197,6 +337,7 @@ func (controller *PostController) NewPostUserStatusHandler() fiber.Handler {
 				claims.UID,
 				isLiked,
 				isFavourited,
+				isReposted,
 			),
 		),
 		)
@@ -256,7 +397,11 @@ func (controller *PostController) NewLikePostHandler() fiber.Handler {
 			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "post id must be a number"))
 		}
 
-		if err := controller.postService.LikePost(int64(claims.UID), int64(postIDUint)); err != nil {
+		err = controller.postService.LikePost(int64(claims.UID), int64(postIDUint))
+		if errors.Is(err, services.ErrBlocked) {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()))
+		}
+		if err != nil {
 			return ctx.Status(200).JSON(serializers.NewResponse(consts.SERVER_ERROR, err.Error()))
 		}
 
@@ -304,7 +449,11 @@ func (controller *PostController) NewFavouritePostHandler() fiber.Handler {
 			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "post id must be a number"))
 		}
 
-		if err := controller.postService.FavouritePost(int64(claims.UID), int64(postIDUint)); err != nil {
+		err = controller.postService.FavouritePost(int64(claims.UID), int64(postIDUint))
+		if errors.Is(err, services.ErrBlocked) {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()))
+		}
+		if err != nil {
 			return ctx.Status(200).JSON(serializers.NewResponse(consts.SERVER_ERROR, err.Error()))
 		}
 
@@ -336,9 +485,63 @@ func (controller *PostController) NewCancelFavouritePostHandler() fiber.Handler
 	}
 }
 
+func (controller *PostController) NewRepostPostHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		postID := ctx.Query("post-id")
+
+		if postID == "" {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "post id cannot be empty"))
+		}
+
+		postIDUint, err := strconv.ParseUint(postID, 10, 64)
+		if err != nil {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "post id must be a number"))
+		}
+
+		err = controller.postService.RepostPost(int64(claims.UID), int64(postIDUint))
+		if errors.Is(err, services.ErrPrivateAccount) || errors.Is(err, services.ErrBlocked) {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()))
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.SERVER_ERROR, err.Error()))
+		}
+
+		return ctx.JSON(serializers.NewResponse(consts.SUCCESS, "succeed"))
+	}
+}
+
+func (controller *PostController) NewCancelRepostPostHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		postID := ctx.Query("post-id")
+
+		if postID == "" {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "post id cannot be empty"))
+		}
+
+		postIDUint, err := strconv.ParseUint(postID, 10, 64)
+		if err != nil {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "post id must be a number"))
+		}
+
+		if err := controller.postService.CancelRepostPost(int64(claims.UID), int64(postIDUint)); err != nil {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.SERVER_ERROR, err.Error()))
+		}
+
+		return ctx.JSON(serializers.NewResponse(consts.SUCCESS, "succeed"))
+	}
+}
+
 func (controller *PostController) NewDeletePostHandler() fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
 		postID := ctx.Params("post")
 
 		if postID == "" {
@@ -350,7 +553,14 @@ func (controller *PostController) NewDeletePostHandler() fiber.Handler {
 			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "post id must be a number"))
 		}
 
-		if err := controller.postService.DeletePost(postIDUint); err != nil {
+		err = controller.postService.DeletePost(claims.UID, postIDUint)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "post does not exist"))
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()))
+		}
+		if err != nil {
 			return ctx.Status(200).JSON(serializers.NewResponse(consts.SERVER_ERROR, err.Error()))
 		}
 
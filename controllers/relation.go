@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/services"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type RelationController struct {
+	relationService *services.RelationService
+}
+
+func (factory *Factory) NewRelationController() *RelationController {
+	return &RelationController{
+		relationService: factory.serviceFactory.NewRelationService(),
+	}
+}
+
+func (controller *RelationController) newRelationHandler(action func(uid, targetID uint64) error) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		body := struct {
+			UserID uint64 `json:"user_id" form:"user_id"`
+		}{}
+		err := ctx.BodyParser(&body)
+		if err != nil || body.UserID == 0 {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, "user_id is required"))
+		}
+
+		err = action(claims.UID, body.UserID)
+		if err != nil {
+			return ctx.Status(200).JSON(serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()))
+		}
+
+		return ctx.JSON(serializers.NewResponse(consts.SUCCESS, "succeed"))
+	}
+}
+
+func (controller *RelationController) newRelationListHandler(list func(uid uint64) ([]models.RelationInfo, error)) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		relations, err := list(claims.UID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewRelationListResponse(relations)),
+		)
+	}
+}
+
+func (controller *RelationController) NewBlockHandler() fiber.Handler {
+	return controller.newRelationHandler(controller.relationService.BlockUser)
+}
+
+func (controller *RelationController) NewUnblockHandler() fiber.Handler {
+	return controller.newRelationHandler(controller.relationService.UnblockUser)
+}
+
+func (controller *RelationController) NewMuteHandler() fiber.Handler {
+	return controller.newRelationHandler(controller.relationService.MuteUser)
+}
+
+func (controller *RelationController) NewUnmuteHandler() fiber.Handler {
+	return controller.newRelationHandler(controller.relationService.UnmuteUser)
+}
+
+func (controller *RelationController) NewBlockListHandler() fiber.Handler {
+	return controller.newRelationListHandler(controller.relationService.GetBlockList)
+}
+
+func (controller *RelationController) NewMuteListHandler() fiber.Handler {
+	return controller.newRelationListHandler(controller.relationService.GetMuteList)
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -46,6 +47,11 @@ func (controller *ReplyController) NewCreateReplyHandler(commentStore *stores.Co
 		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
 
 		err := controller.replyService.CreateReply(claims.UID, reqBody.CommentID, reqBody.ParentReplyID, reqBody.Content, commentStore, userStore)
+		if errors.Is(err, services.ErrBlocked) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
 		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
@@ -76,7 +82,13 @@ func (controller *ReplyController) DeleteReplyHandler() fiber.Handler {
 
 		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
 
-		if err := controller.replyService.DeleteReply(claims.UID, reqBody.ReplyID); err != nil {
+		err := controller.replyService.DeleteReply(claims.UID, reqBody.ReplyID)
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
 			)
@@ -108,6 +120,11 @@ func (controller *ReplyController) NewUpdateReplyHandler() fiber.Handler {
 		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
 
 		err = controller.replyService.UpdateReply(claims.UID, reqBody.ReplyID, reqBody.Content)
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, err.Error()),
+			)
+		}
 		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
@@ -136,7 +153,12 @@ func (controller *ReplyController) NewGetReplyListHandler() fiber.Handler {
 			)
 		}
 
-		replyList, err := controller.replyService.GetReplyList(commentIDUint64)
+		var viewerUID uint64
+		if claims, ok := ctx.Locals("claims").(*types.BearerTokenClaims); ok {
+			viewerUID = claims.UID
+		}
+
+		replyList, err := controller.replyService.GetReplyList(commentIDUint64, viewerUID)
 		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
@@ -172,8 +194,15 @@ func (controller *ReplyController) NewGetReplyDetailHandler() fiber.Handler {
 			)
 		}
 
+		entities, err := controller.replyService.GetReplyEntities(replyIDUint64)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
 		return ctx.Status(200).JSON(
-			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewReplyDetailResponse(reply)),
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewReplyDetailResponse(reply, entities)),
 		)
 	}
 }
@@ -2,11 +2,13 @@ package controllers
 
 import (
 	"net/url"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/mehakhanaa/complex-micro-blog/consts"
 	search "github.com/mehakhanaa/complex-micro-blog/proto"
 	"github.com/mehakhanaa/complex-micro-blog/services"
+	"github.com/mehakhanaa/complex-micro-blog/types"
 	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
 )
 
@@ -37,7 +39,184 @@ func (controller *SearchController) NewSearchPostHandler() fiber.Handler {
 			)
 		}
 
-		result, err := controller.searchService.SearchPost(decodedQueryString)
+		length := ctx.Query("len")
+		offset := ctx.Query("offset")
+		if length != "" {
+			_, err := strconv.ParseUint(length, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid length"),
+				)
+			}
+		}
+		if offset != "" {
+			_, err := strconv.ParseUint(offset, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid offset"),
+				)
+			}
+		}
+
+		filter := new(search.SearchFilter)
+		if uid := ctx.Query("uid"); uid != "" {
+			uidUint, err := strconv.ParseUint(uid, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid uid"),
+				)
+			}
+			filter.Uids = []uint64{uidUint}
+		}
+		if since := ctx.Query("since"); since != "" {
+			filter.CreatedAfter, err = strconv.ParseInt(since, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid since timestamp"),
+				)
+			}
+		}
+		if until := ctx.Query("until"); until != "" {
+			filter.CreatedBefore, err = strconv.ParseInt(until, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid until timestamp"),
+				)
+			}
+		}
+		if hasImages := ctx.Query("has-images"); hasImages != "" {
+			hasImagesBool, err := strconv.ParseBool(hasImages)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid has-images"),
+				)
+			}
+			filter.HasImages = &hasImagesBool
+		}
+
+		var viewerUID uint64
+		if claims, ok := ctx.Locals("claims").(*types.BearerTokenClaims); ok {
+			viewerUID = claims.UID
+		}
+
+		hits, total, err := controller.searchService.SearchPost(decodedQueryString, length, offset, filter, viewerUID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "", serializers.NewSearchHitListResponse(hits, total)),
+		)
+	}
+}
+
+func (controller *SearchController) NewSearchUserHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		queryString := ctx.Query("q")
+		if queryString == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "query content is required"),
+			)
+		}
+
+		decodedQueryString, err := url.QueryUnescape(queryString)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "query content is invalid"),
+			)
+		}
+
+		length := ctx.Query("len")
+		if length != "" {
+			_, err := strconv.ParseUint(length, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid length"),
+				)
+			}
+		}
+
+		var viewerUID uint64
+		if claims, ok := ctx.Locals("claims").(*types.BearerTokenClaims); ok {
+			viewerUID = claims.UID
+		}
+
+		users, err := controller.searchService.SearchUser(decodedQueryString, length, viewerUID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "", serializers.NewUserSearchResponse(users)),
+		)
+	}
+}
+
+func (controller *SearchController) NewSearchCommentHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		queryString := ctx.Query("q")
+		if queryString == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "query content is required"),
+			)
+		}
+
+		decodedQueryString, err := url.QueryUnescape(queryString)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "query content is invalid"),
+			)
+		}
+
+		length := ctx.Query("len")
+		offset := ctx.Query("offset")
+		if length != "" {
+			_, err := strconv.ParseUint(length, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid length"),
+				)
+			}
+		}
+		if offset != "" {
+			_, err := strconv.ParseUint(offset, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid offset"),
+				)
+			}
+		}
+
+		var postID, uid uint64
+		if postIDString := ctx.Query("post-id"); postIDString != "" {
+			postID, err = strconv.ParseUint(postIDString, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid post id"),
+				)
+			}
+		}
+		if uidString := ctx.Query("uid"); uidString != "" {
+			uid, err = strconv.ParseUint(uidString, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid uid"),
+				)
+			}
+		}
+
+		var viewerUID uint64
+		if claims, ok := ctx.Locals("claims").(*types.BearerTokenClaims); ok {
+			viewerUID = claims.UID
+		}
+
+		hits, total, err := controller.searchService.SearchComment(decodedQueryString, length, offset, postID, uid, viewerUID)
 		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
@@ -45,7 +224,7 @@ func (controller *SearchController) NewSearchPostHandler() fiber.Handler {
 		}
 
 		return ctx.Status(200).JSON(
-			serializers.NewResponse(consts.SUCCESS, "", serializers.NewPostListResponse(result.Ids)),
+			serializers.NewResponse(consts.SUCCESS, "", serializers.NewSearchHitListResponse(hits, total)),
 		)
 	}
 }
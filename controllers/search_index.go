@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+	"github.com/mehakhanaa/complex-micro-blog/services"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type SearchIndexController struct {
+	searchIndexService *services.SearchIndexService
+}
+
+func (factory *Factory) NewSearchIndexController(searchServiceClient search.SearchEngineClient) *SearchIndexController {
+	return &SearchIndexController{
+		searchIndexService: factory.serviceFactory.NewSearchIndexService(searchServiceClient),
+	}
+}
+
+func (controller *SearchIndexController) NewRebuildProgressHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		progress, err := controller.searchIndexService.GetRebuildProgress()
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewSearchRebuildProgressResponse(progress)),
+		)
+	}
+}
+
+func (controller *SearchIndexController) NewStartRebuildHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		reqBody := new(types.SearchRebuildBody)
+		if len(ctx.Body()) > 0 {
+			if err := ctx.BodyParser(reqBody); err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+				)
+			}
+		}
+
+		progress, err := controller.searchIndexService.StartRebuild(reqBody.Restart)
+		if errors.Is(err, services.ErrSearchRebuildRunning) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "search index rebuild scheduled", serializers.NewSearchRebuildProgressResponse(progress)),
+		)
+	}
+}
+
+func (controller *SearchIndexController) NewVerifyHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		reqBody := new(types.SearchVerifyBody)
+		if len(ctx.Body()) > 0 {
+			if err := ctx.BodyParser(reqBody); err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+				)
+			}
+		}
+
+		if reqBody.Sample < 0 {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "invalid sample size"),
+			)
+		}
+		sample := reqBody.Sample
+		if sample == 0 {
+			sample = consts.SEARCH_VERIFY_DEFAULT_SAMPLE
+		}
+		sample = min(sample, consts.SEARCH_VERIFY_MAX_SAMPLE)
+
+		result, err := controller.searchIndexService.VerifyIndex(sample, reqBody.Repair)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewSearchVerifyResponse(result, reqBody.Repair)),
+		)
+	}
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"bufio"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/services"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type StreamController struct {
+	streamService *services.StreamService
+}
+
+func (factory *Factory) NewStreamController() *StreamController {
+	return &StreamController{
+		streamService: factory.serviceFactory.NewStreamService(),
+	}
+}
+
+func (controller *StreamController) NewStreamHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		var postIDs []uint64
+		if postIDsString := ctx.Query("post-ids"); postIDsString != "" {
+			for _, postIDString := range strings.Split(postIDsString, ",") {
+				postID, err := strconv.ParseUint(strings.TrimSpace(postIDString), 10, 64)
+				if err != nil {
+					return ctx.Status(200).JSON(
+						serializers.NewResponse(consts.PARAMETER_ERROR, "post id must be a number"),
+					)
+				}
+				postIDs = append(postIDs, postID)
+			}
+		}
+		if len(postIDs) > consts.STREAM_MAX_POST_SUBSCRIPTIONS {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "too many post ids"),
+			)
+		}
+
+		pubsub, err := controller.streamService.Subscribe(claims.UID, postIDs)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		ctx.Set("Content-Type", "text/event-stream")
+		ctx.Set("Cache-Control", "no-cache")
+		ctx.Set("Connection", "keep-alive")
+		ctx.Set("X-Accel-Buffering", "no")
+
+		ctx.Context().SetBodyStreamWriter(func(writer *bufio.Writer) {
+			defer pubsub.Close()
+
+			heartbeat := time.NewTicker(consts.STREAM_HEARTBEAT_INTERVAL * time.Second)
+			defer heartbeat.Stop()
+
+			tokenCheck := time.NewTicker(consts.STREAM_TOKEN_CHECK_INTERVAL * time.Second)
+			defer tokenCheck.Stop()
+
+			writer.WriteString(": connected\n\n")
+			if err := writer.Flush(); err != nil {
+				return
+			}
+
+			messages := pubsub.Channel()
+			for {
+				select {
+				case message, ok := <-messages:
+					if !ok {
+						return
+					}
+					writer.WriteString("data: ")
+					writer.WriteString(message.Payload)
+					writer.WriteString("\n\n")
+				case <-heartbeat.C:
+					writer.WriteString(": heartbeat\n\n")
+				case <-tokenCheck.C:
+					avaliable, err := controller.streamService.IsTokenAvaliable(claims)
+					if err != nil || avaliable {
+						continue
+					}
+					payload, _ := json.Marshal(types.StreamEvent{Type: types.STREAM_EVENT_TOKEN_EXPIRED})
+					writer.WriteString("data: ")
+					writer.Write(payload)
+					writer.WriteString("\n\n")
+					writer.Flush()
+					return
+				}
+
+				if err := writer.Flush(); err != nil {
+					return
+				}
+			}
+		})
+
+		return nil
+	}
+}
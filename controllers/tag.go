@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/services"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type TagController struct {
+	tagService *services.TagService
+}
+
+func (factory *Factory) NewTagController() *TagController {
+	return &TagController{
+		tagService: factory.serviceFactory.NewTagService(),
+	}
+}
+
+func (controller *TagController) NewTagPostListHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		tag := strings.TrimSpace(ctx.Query("tag"))
+		length := ctx.Query("len")
+		from := ctx.Query("from")
+		if tag == "" || tag == "#" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "tag is required"),
+			)
+		}
+		if length != "" {
+			_, err := strconv.ParseUint(length, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid length"),
+				)
+			}
+		}
+		if from != "" {
+			_, err := strconv.ParseUint(from, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid from id"),
+				)
+			}
+		}
+
+		var viewerUID uint64
+		if claims, ok := ctx.Locals("claims").(*types.BearerTokenClaims); ok {
+			viewerUID = claims.UID
+		}
+
+		posts, err := controller.tagService.GetTagPostList(tag, length, from, viewerUID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewPostListResponse(posts)),
+		)
+	}
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/services"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type TrendingController struct {
+	trendingService *services.TrendingService
+}
+
+func (factory *Factory) NewTrendingController() *TrendingController {
+	return &TrendingController{
+		trendingService: factory.serviceFactory.NewTrendingService(),
+	}
+}
+
+func (controller *TrendingController) NewTrendingPostListHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		var viewerUID uint64
+		if claims, ok := ctx.Locals("claims").(*types.BearerTokenClaims); ok {
+			viewerUID = claims.UID
+		}
+
+		window, posts, err := controller.trendingService.GetTrendingPosts(viewerUID, ctx.Query("window"), ctx.Query("len"))
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewTrendingPostListResponse(window, posts)),
+		)
+	}
+}
+
+func (controller *TrendingController) NewTrendingTagListHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		window, tags, err := controller.trendingService.GetTrendingTags(ctx.Query("window"), ctx.Query("len"))
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewTrendingTagListResponse(window, tags)),
+		)
+	}
+}
@@ -78,7 +78,7 @@ func (controller *UserController) NewProfileHandler() fiber.Handler {
 func (controller *UserController) NewRegisterHandler() fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 
-		reqBody := new(types.UserAuthBody)
+		reqBody := new(types.UserRegisterBody)
 		err := ctx.BodyParser(reqBody)
 		if err != nil {
 			return ctx.Status(200).JSON(
@@ -92,7 +92,7 @@ func (controller *UserController) NewRegisterHandler() fiber.Handler {
 			)
 		}
 
-		err = controller.userService.RegisterUser(reqBody.Username, reqBody.Password)
+		err = controller.userService.RegisterUser(reqBody.Username, reqBody.Password, reqBody.Email)
 		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
@@ -134,15 +134,219 @@ func (controller *UserController) NewLoginHandler() fiber.Handler {
 
 		os := ua.OSInfo().FullName
 
-		token, err := controller.userService.LoginUser(reqBody.Username, reqBody.Password, ctx.IP(), browserInfo, os)
+		tokenPair, challengeToken, err := controller.userService.LoginUser(reqBody.Username, reqBody.Password, ctx.IP(), browserInfo, os)
+		var lockedErr *services.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.FormatInt(int64(lockedErr.RetryAfter.Seconds()), 10))
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.LOGIN_LOCKED_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if challengeToken != "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SUCCESS, "two-factor authentication required", serializers.NewTwoFactorChallenge(challengeToken, consts.LOGIN_CHALLENGE_EXPIRE_TIME)),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewUserToken(tokenPair)),
+		)
+	}
+}
+
+func (controller *UserController) NewTwoFactorLoginHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		reqBody := new(types.UserTwoFactorLoginBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.ChallengeToken == "" || reqBody.Code == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "challenge token or code is required"),
+			)
+		}
+
+		tokenPair, err := controller.userService.LoginWithTwoFactor(reqBody.ChallengeToken, reqBody.Code)
+		var lockedErr *services.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.FormatInt(int64(lockedErr.RetryAfter.Seconds()), 10))
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.LOGIN_LOCKED_ERROR, err.Error()),
+			)
+		}
+		if errors.Is(err, services.ErrLoginChallengeInvalid) || errors.Is(err, services.ErrTwoFactorCodeInvalid) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.AUTH_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewUserToken(tokenPair)),
+		)
+	}
+}
+
+func (controller *UserController) NewRefreshTokenHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		reqBody := new(types.UserRefreshTokenBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.RefreshToken == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "refresh token is required"),
+			)
+		}
+
+		tokenPair, err := controller.userService.RefreshToken(reqBody.RefreshToken)
+		if errors.Is(err, services.ErrRefreshTokenInvalid) || errors.Is(err, services.ErrRefreshTokenReused) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.AUTH_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewUserToken(tokenPair)),
+		)
+	}
+}
+
+func (controller *UserController) NewLogoutHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		err := controller.userService.LogoutUser(claims)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed"),
+		)
+	}
+}
+
+func (controller *UserController) NewSessionListHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		sessions, loginLogs, err := controller.userService.GetSessionList(claims.UID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(
+				consts.SUCCESS,
+				"succeed",
+				serializers.NewUserSessionListResponse(sessions, loginLogs, claims.SessionID),
+			),
+		)
+	}
+}
+
+func (controller *UserController) NewLoginHistoryHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		length := ctx.Query("len")
+		from := ctx.Query("from")
+		if length != "" {
+			_, err := strconv.ParseUint(length, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid length"),
+				)
+			}
+		}
+		if from != "" {
+			_, err := strconv.ParseUint(from, 10, 64)
+			if err != nil {
+				return ctx.Status(200).JSON(
+					serializers.NewResponse(consts.PARAMETER_ERROR, "invalid from cursor"),
+				)
+			}
+		}
+
+		loginLogs, err := controller.userService.GetLoginHistory(claims.UID, length, from)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewUserLoginHistoryResponse(loginLogs)),
+		)
+	}
+}
+
+func (controller *UserController) NewRevokeSessionHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		reqBody := new(types.UserSessionRevokeBody)
+		err := ctx.BodyParser(reqBody)
 		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
 			)
 		}
 
+		if reqBody.SessionID == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "session id is required"),
+			)
+		}
+
+		err = controller.userService.RevokeSession(claims.UID, reqBody.SessionID)
+		if errors.Is(err, services.ErrSessionNotFound) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
 		return ctx.Status(200).JSON(
-			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewUserToken(token)),
+			serializers.NewResponse(consts.SUCCESS, "succeed"),
 		)
 	}
 }
@@ -199,7 +403,15 @@ func (controller *UserController) NewUpdatePasswordHandler() fiber.Handler {
 			reqBody.Username,
 			reqBody.Password,
 			reqBody.NewPassword,
+			ctx.IP(),
 		)
+		var lockedErr *services.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.FormatInt(int64(lockedErr.RetryAfter.Seconds()), 10))
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.LOGIN_LOCKED_ERROR, err.Error()),
+			)
+		}
 		if err != nil {
 			return ctx.Status(200).JSON(
 				serializers.NewResponse(consts.AUTH_ERROR, err.Error()),
@@ -243,3 +455,367 @@ func (controller *UserController) NewUpdateProfileHandler() fiber.Handler {
 		)
 	}
 }
+
+func (controller *UserController) NewRequestUnlockHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		reqBody := new(types.UserUnlockBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.Username == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "username is required"),
+			)
+		}
+
+		err = controller.userService.RequestUnlock(reqBody.Username)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "if the account has a verified email, an unlock token has been sent"),
+		)
+	}
+}
+
+func (controller *UserController) NewUnlockHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		reqBody := new(types.UserUnlockTokenBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.Token == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "token is required"),
+			)
+		}
+
+		err = controller.userService.UnlockUserByToken(reqBody.Token, ctx.IP())
+		if errors.Is(err, services.ErrVerificationTokenInvalid) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.AUTH_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "account unlocked successfully"),
+		)
+	}
+}
+
+func (controller *UserController) NewAdminUnlockHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		reqBody := new(types.UserUnlockBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.Username == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "username is required"),
+			)
+		}
+
+		err = controller.userService.UnlockUser(claims.UID, reqBody.Username, ctx.IP())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "user does not exist"),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed"),
+		)
+	}
+}
+
+func (controller *UserController) NewUpdateEmailHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		reqBody := new(types.UserEmailBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.Email == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "email is required"),
+			)
+		}
+
+		err = controller.userService.UpdateEmail(claims.UID, reqBody.Email)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "verification email sent"),
+		)
+	}
+}
+
+func (controller *UserController) NewResendEmailVerificationHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		err := controller.userService.ResendEmailVerification(claims.UID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "verification email sent"),
+		)
+	}
+}
+
+func (controller *UserController) NewVerifyEmailHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		token := ctx.Query("token")
+		if token == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "token is required"),
+			)
+		}
+
+		err := controller.userService.VerifyEmail(token)
+		if errors.Is(err, services.ErrVerificationTokenInvalid) || errors.Is(err, services.ErrEmailAlreadyUsed) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "email verified"),
+		)
+	}
+}
+
+func (controller *UserController) NewForgotPasswordHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		reqBody := new(types.UserEmailBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.Email == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "email is required"),
+			)
+		}
+
+		err = controller.userService.ForgotPassword(reqBody.Email)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "if the email is registered and verified, a reset token has been sent"),
+		)
+	}
+}
+
+func (controller *UserController) NewResetPasswordHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		reqBody := new(types.UserPasswordResetBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.Token == "" || reqBody.NewPassword == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "token or new password is required"),
+			)
+		}
+
+		err = controller.userService.ResetPassword(reqBody.Token, reqBody.NewPassword)
+		if errors.Is(err, services.ErrVerificationTokenInvalid) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.AUTH_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "password reset successfully"),
+		)
+	}
+}
+
+func (controller *UserController) NewEnrollTwoFactorHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		secret, uri, err := controller.userService.EnrollTwoFactor(claims.UID)
+		if errors.Is(err, services.ErrTwoFactorAlreadyEnabled) {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewTwoFactorEnrollment(secret, uri)),
+		)
+	}
+}
+
+func (controller *UserController) NewConfirmTwoFactorHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		reqBody := new(types.UserTwoFactorCodeBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.Code == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "code is required"),
+			)
+		}
+
+		recoveryCodes, err := controller.userService.ConfirmTwoFactor(claims.UID, reqBody.Code)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewRecoveryCodes(recoveryCodes)),
+		)
+	}
+}
+
+func (controller *UserController) NewDisableTwoFactorHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		reqBody := new(types.UserTwoFactorDisableBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.Password == "" || reqBody.Code == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "password or code is required"),
+			)
+		}
+
+		err = controller.userService.DisableTwoFactor(claims.UID, reqBody.Password, reqBody.Code)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed"),
+		)
+	}
+}
+
+func (controller *UserController) NewRegenerateRecoveryCodesHandler() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims := ctx.Locals("claims").(*types.BearerTokenClaims)
+
+		reqBody := new(types.UserTwoFactorCodeBody)
+		err := ctx.BodyParser(reqBody)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		if reqBody.Code == "" {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, "code is required"),
+			)
+		}
+
+		recoveryCodes, err := controller.userService.RegenerateRecoveryCodes(claims.UID, reqBody.Code)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.PARAMETER_ERROR, err.Error()),
+			)
+		}
+
+		return ctx.Status(200).JSON(
+			serializers.NewResponse(consts.SUCCESS, "succeed", serializers.NewRecoveryCodes(recoveryCodes)),
+		)
+	}
+}
@@ -0,0 +1,43 @@
+package crons
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+)
+
+type AccountDeletionJob struct {
+	logger       *logrus.Logger
+	accountStore *stores.AccountStore
+}
+
+func NewAccountDeletionJob(logger *logrus.Logger, accountStore *stores.AccountStore) *AccountDeletionJob {
+	return &AccountDeletionJob{
+		logger:       logger,
+		accountStore: accountStore,
+	}
+}
+
+func (job *AccountDeletionJob) Run() {
+	job.logger.Debugln("Account deletion job init...")
+
+	accountDeletions, err := job.accountStore.GetDueAccountDeletions(time.Now(), consts.ACCOUNT_DELETION_BATCH_SIZE)
+	if err != nil {
+		job.logger.Errorln("Error in account deletion job:", err)
+		return
+	}
+
+	for _, accountDeletion := range accountDeletions {
+		err := job.accountStore.DeleteAccount(accountDeletion.UID)
+		if err != nil {
+			job.logger.Errorln("Error in account deletion job:", accountDeletion.UID, err)
+			continue
+		}
+		job.logger.Infoln("Account deleted:", accountDeletion.UID)
+	}
+
+	job.logger.Debugln("Account deletion job done")
+}
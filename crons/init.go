@@ -4,12 +4,15 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
 
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
 	"github.com/mehakhanaa/complex-micro-blog/utils/jobs"
 )
 
-func InitJobs(logger *logrus.Logger, db *gorm.DB, redisClient *redis.Client) {
+func InitJobs(logger *logrus.Logger, db *gorm.DB, redisClient *redis.Client, mongoClient *mongo.Client, storeFactory *stores.Factory, searchServiceClient search.SearchEngineClient) {
 
 	crontab := cron.New()
 
@@ -23,7 +26,27 @@ func InitJobs(logger *logrus.Logger, db *gorm.DB, redisClient *redis.Client) {
 		logger.Panicln(err.Error())
 	}
 
-	_, err = jobs.AddSkipIfStillRunningJob(crontab, "@every 1h", NewTokenCleanJob(logger, redisClient))
+	_, err = jobs.AddSkipIfStillRunningJob(crontab, "@every 10m", NewTrendingJob(logger, db, redisClient, mongoClient))
+	if err != nil {
+		logger.Panicln(err.Error())
+	}
+
+	_, err = jobs.AddSkipIfStillRunningJob(crontab, "@every 1m", NewLoginAnomalyJob(logger, db, storeFactory.NewNotificationStore()))
+	if err != nil {
+		logger.Panicln(err.Error())
+	}
+
+	_, err = jobs.AddSkipIfStillRunningJob(crontab, "@every 1h", NewAccountDeletionJob(logger, storeFactory.NewAccountStore()))
+	if err != nil {
+		logger.Panicln(err.Error())
+	}
+
+	_, err = jobs.AddSkipIfStillRunningJob(crontab, "@every 5s", NewOutboxDispatchJob(logger, storeFactory, searchServiceClient))
+	if err != nil {
+		logger.Panicln(err.Error())
+	}
+
+	_, err = jobs.AddSkipIfStillRunningJob(crontab, "@every 10s", NewSearchRebuildJob(logger, storeFactory, searchServiceClient))
 	if err != nil {
 		logger.Panicln(err.Error())
 	}
@@ -0,0 +1,161 @@
+package crons
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type LoginAnomalyJob struct {
+	logger            *logrus.Logger
+	db                *gorm.DB
+	notificationStore *stores.NotificationStore
+}
+
+func NewLoginAnomalyJob(logger *logrus.Logger, db *gorm.DB, notificationStore *stores.NotificationStore) *LoginAnomalyJob {
+	return &LoginAnomalyJob{
+		logger:            logger,
+		db:                db,
+		notificationStore: notificationStore,
+	}
+}
+
+func (job *LoginAnomalyJob) Run() {
+	job.logger.Debugln("Login anomaly job init...")
+
+	var loginLogs []models.UserLoginLog
+	result := job.db.
+		Where("if_checked = ?", false).
+		Order("id ASC").
+		Limit(consts.LOGIN_ANOMALY_BATCH_SIZE).
+		Find(&loginLogs)
+	if result.Error != nil {
+		job.logger.Errorln("Error in login anomaly job:", result.Error)
+		return
+	}
+
+	for index := range loginLogs {
+		loginLog := &loginLogs[index]
+
+		notificationType, reason, err := job.review(loginLog)
+		if err != nil {
+			job.logger.Errorln("Error in login anomaly job:", err)
+			return
+		}
+
+		result := job.db.Model(loginLog).Updates(map[string]interface{}{
+			"if_checked":  true,
+			"is_flagged":  notificationType != "",
+			"flag_reason": reason,
+		})
+		if result.Error != nil {
+			job.logger.Errorln("Error in login anomaly job:", result.Error)
+			return
+		}
+
+		if notificationType == "" {
+			continue
+		}
+
+		job.logger.Infoln("Login anomaly flagged for uid", loginLog.UID, "-", reason)
+
+		err = job.notificationStore.CreateNotification(
+			loginLog.UID,
+			0,
+			notificationType,
+			types.NOTIFICATION_TARGET_LOGIN,
+			uint64(loginLog.ID),
+		)
+		if err != nil {
+			job.logger.Errorln("Error in login anomaly job:", err)
+		}
+	}
+
+	job.logger.Debugln("Login anomaly job done")
+}
+
+func (job *LoginAnomalyJob) review(loginLog *models.UserLoginLog) (types.NotificationType, string, error) {
+
+	if loginLog.UID == 0 {
+		return "", "", nil
+	}
+
+	burstSince := loginLog.LoginTime.Add(-consts.LOGIN_ANOMALY_BURST_WINDOW * time.Second)
+
+	if !loginLog.IsSucceed {
+		if loginLog.Reason == consts.LOGIN_REASON_TWO_FACTOR_CHALLENGE {
+			return "", "", nil
+		}
+
+		var failures int64
+		result := job.db.Model(&models.UserLoginLog{}).
+			Where("uid = ? AND is_succeed = ? AND login_time > ? AND id <= ?", loginLog.UID, false, burstSince, loginLog.ID).
+			Where("reason <> ?", consts.LOGIN_REASON_TWO_FACTOR_CHALLENGE).
+			Count(&failures)
+		if result.Error != nil {
+			return "", "", result.Error
+		}
+		if failures == consts.LOGIN_ANOMALY_BURST_FAILURE_COUNT {
+			return types.NOTIFICATION_TYPE_SUSPICIOUS_LOGIN, "repeated login failures", nil
+		}
+		return "", "", nil
+	}
+
+	var burstIPs int64
+	result := job.db.Model(&models.UserLoginLog{}).
+		Where("uid = ? AND is_succeed = ? AND login_time > ? AND id <= ?", loginLog.UID, true, burstSince, loginLog.ID).
+		Distinct("login_ip").
+		Count(&burstIPs)
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+	if burstIPs >= consts.LOGIN_ANOMALY_BURST_IP_COUNT {
+		return types.NOTIFICATION_TYPE_SUSPICIOUS_LOGIN, "sign-ins from several ips in a short time", nil
+	}
+
+	previous := job.db.Model(&models.UserLoginLog{}).
+		Where("uid = ? AND is_succeed = ? AND id < ?", loginLog.UID, true, loginLog.ID)
+
+	var previousLogins int64
+	result = previous.Session(&gorm.Session{}).Count(&previousLogins)
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+	if previousLogins == 0 {
+		return "", "", nil
+	}
+
+	var knownIP int64
+	result = previous.Session(&gorm.Session{}).Where("login_ip = ?", loginLog.LoginIP).Count(&knownIP)
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+
+	var knownDevice int64
+	result = previous.Session(&gorm.Session{}).
+		Where("device = ? AND application = ?", loginLog.Device, loginLog.Application).
+		Count(&knownDevice)
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+
+	var reasons []string
+	if knownIP == 0 {
+		reasons = append(reasons, "new ip")
+	}
+	if knownDevice == 0 {
+		reasons = append(reasons, "new device")
+	}
+	if len(reasons) == 0 {
+		return "", "", nil
+	}
+
+	return types.NOTIFICATION_TYPE_NEW_SIGN_IN, strings.Join(reasons, ", "), nil
+}
@@ -0,0 +1,291 @@
+package crons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+	"github.com/mehakhanaa/complex-micro-blog/searchers"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type OutboxDispatchJob struct {
+	logger              *logrus.Logger
+	outboxStore         *stores.OutboxStore
+	postStore           *stores.PostStore
+	userStore           *stores.UserStore
+	commentStore        *stores.CommentStore
+	followStore         *stores.FollowStore
+	timelineStore       *stores.TimelineStore
+	relationStore       *stores.RelationStore
+	searchServiceClient search.SearchEngineClient
+}
+
+func NewOutboxDispatchJob(logger *logrus.Logger, storeFactory *stores.Factory, searchServiceClient search.SearchEngineClient) *OutboxDispatchJob {
+	return &OutboxDispatchJob{
+		logger:              logger,
+		outboxStore:         storeFactory.NewOutboxStore(),
+		postStore:           storeFactory.NewPostStore(),
+		userStore:           storeFactory.NewUserStore(),
+		commentStore:        storeFactory.NewCommentStore(),
+		followStore:         storeFactory.NewFollowStore(),
+		timelineStore:       storeFactory.NewTimelineStore(),
+		relationStore:       storeFactory.NewRelationStore(),
+		searchServiceClient: searchServiceClient,
+	}
+}
+
+func (job *OutboxDispatchJob) Run() {
+	job.logger.Debugln("Outbox dispatch job init...")
+
+	for {
+		events, err := job.outboxStore.ClaimOutboxEvents(consts.OUTBOX_BATCH_SIZE, consts.OUTBOX_LEASE_DURATION*time.Second)
+		if err != nil {
+			job.logger.Errorln("Error in outbox dispatch job:", err)
+			return
+		}
+
+		for _, event := range events {
+			err := job.deliver(event)
+			if err == nil {
+				err = job.outboxStore.CompleteOutboxEvent(event.ID)
+				if err != nil {
+					job.logger.Errorln("Error in outbox dispatch job:", event.ID, err)
+				}
+				continue
+			}
+
+			attempts := event.Attempts + 1
+			if attempts >= consts.OUTBOX_MAX_ATTEMPTS {
+				job.logger.Errorln("Outbox event marked dead:", event.ID, event.Type, event.AggregateID, "after", attempts, "attempts", err)
+				err = job.outboxStore.MarkOutboxEventDead(event.ID, attempts, err.Error())
+				if err != nil {
+					job.logger.Errorln("Error in outbox dispatch job:", event.ID, err)
+				}
+				continue
+			}
+			job.logger.Warnln("Outbox event delivery failed:", event.ID, event.Type, event.AggregateID, "attempt", attempts, err)
+			err = job.outboxStore.RetryOutboxEvent(event.ID, attempts, time.Now().Add(outboxRetryDelay(attempts)), err.Error())
+			if err != nil {
+				job.logger.Errorln("Error in outbox dispatch job:", event.ID, err)
+			}
+		}
+
+		if len(events) < consts.OUTBOX_BATCH_SIZE {
+			break
+		}
+	}
+
+	job.logger.Debugln("Outbox dispatch job done")
+}
+
+func (job *OutboxDispatchJob) deliver(event models.OutboxEvent) error {
+
+	var (
+		code uint64
+		err  error
+	)
+	switch event.Type {
+	case types.OUTBOX_EVENT_POST_INDEX_CREATE, types.OUTBOX_EVENT_POST_INDEX_UPDATE, types.OUTBOX_EVENT_POST_INDEX_DELETE:
+		code, err = job.deliverPost(event)
+	case types.OUTBOX_EVENT_USER_INDEX_CREATE, types.OUTBOX_EVENT_USER_INDEX_UPDATE, types.OUTBOX_EVENT_USER_INDEX_DELETE:
+		code, err = job.deliverUser(event)
+	case types.OUTBOX_EVENT_COMMENT_INDEX_CREATE, types.OUTBOX_EVENT_COMMENT_INDEX_UPDATE, types.OUTBOX_EVENT_COMMENT_INDEX_DELETE:
+		code, err = job.deliverComment(event)
+	case types.OUTBOX_EVENT_POST_TIMELINE_FANOUT:
+		err = job.deliverTimeline(event)
+	case types.OUTBOX_EVENT_POST_QUOTE_CREATE:
+		err = job.deliverQuote(event)
+	default:
+		return fmt.Errorf("unknown outbox event type %s", event.Type)
+	}
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return fmt.Errorf("search service returned code %d", code)
+	}
+
+	return nil
+}
+
+func (job *OutboxDispatchJob) deliverPost(event models.OutboxEvent) (uint64, error) {
+	ctx := context.Background()
+
+	if event.Type == types.OUTBOX_EVENT_POST_INDEX_DELETE {
+		resp, err := job.searchServiceClient.DeletePostIndex(ctx, &search.DeletePostIndexRequest{
+			Ids: []int64{int64(event.AggregateID)},
+		})
+		if err != nil {
+			return 0, err
+		}
+		return resp.Code, nil
+	}
+
+	post, err := job.postStore.GetPost(event.AggregateID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if event.Type == types.OUTBOX_EVENT_POST_INDEX_CREATE {
+		resp, err := job.searchServiceClient.CreatePostIndex(ctx, searchers.NewPostIndexRequest(post))
+		if err != nil {
+			return 0, err
+		}
+		return resp.Code, nil
+	}
+
+	resp, err := job.searchServiceClient.UpdatePostIndex(ctx, searchers.NewPostIndexUpdateRequest(post))
+	if err != nil {
+		return 0, err
+	}
+	return resp.Code, nil
+}
+
+func (job *OutboxDispatchJob) deliverUser(event models.OutboxEvent) (uint64, error) {
+	ctx := context.Background()
+
+	if event.Type == types.OUTBOX_EVENT_USER_INDEX_DELETE {
+		resp, err := job.searchServiceClient.DeleteUserIndex(ctx, &search.DeleteUserIndexRequest{
+			Uids: []uint64{event.AggregateID},
+		})
+		if err != nil {
+			return 0, err
+		}
+		return resp.Code, nil
+	}
+
+	user, err := job.userStore.GetUserByUID(event.AggregateID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var resp *search.UserIndexResponse
+	if event.Type == types.OUTBOX_EVENT_USER_INDEX_CREATE {
+		resp, err = job.searchServiceClient.CreateUserIndex(ctx, searchers.NewUserIndexRequest(*user))
+	} else {
+		resp, err = job.searchServiceClient.UpdateUserIndex(ctx, searchers.NewUserIndexRequest(*user))
+	}
+	if err != nil {
+		return 0, err
+	}
+	return resp.Code, nil
+}
+
+func (job *OutboxDispatchJob) deliverComment(event models.OutboxEvent) (uint64, error) {
+	ctx := context.Background()
+
+	if event.Type == types.OUTBOX_EVENT_COMMENT_INDEX_DELETE {
+		resp, err := job.searchServiceClient.DeleteCommentIndex(ctx, &search.DeleteCommentIndexRequest{
+			Ids: []int64{int64(event.AggregateID)},
+		})
+		if err != nil {
+			return 0, err
+		}
+		return resp.Code, nil
+	}
+
+	comment, err := job.commentStore.GetComment(event.AggregateID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var resp *search.CommentIndexResponse
+	if event.Type == types.OUTBOX_EVENT_COMMENT_INDEX_CREATE {
+		resp, err = job.searchServiceClient.CreateCommentIndex(ctx, searchers.NewCommentIndexRequest(comment))
+	} else {
+		resp, err = job.searchServiceClient.UpdateCommentIndex(ctx, searchers.NewCommentIndexRequest(comment))
+	}
+	if err != nil {
+		return 0, err
+	}
+	return resp.Code, nil
+}
+
+func (job *OutboxDispatchJob) deliverTimeline(event models.OutboxEvent) error {
+
+	post, err := job.postStore.GetPost(event.AggregateID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	followerCount, err := job.followStore.GetFollowersByUID(post.UID)
+	if err != nil {
+		return err
+	}
+	if followerCount > consts.TIMELINE_FANOUT_THRESHOLD {
+		return job.timelineStore.SetCelebrity(post.UID, true)
+	}
+
+	followers, err := job.followStore.GetFollowerList(post.UID)
+	if err != nil {
+		return err
+	}
+
+	followerIDs := make([]uint64, len(followers))
+	for index, follower := range followers {
+		followerIDs[index] = follower.UserID
+	}
+
+	isCelebrity, err := job.timelineStore.IsCelebrity(post.UID)
+	if err != nil {
+		return err
+	}
+	if isCelebrity {
+		err = job.timelineStore.DemoteCelebrity(post.UID, followerIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	muterUIDs, err := job.relationStore.GetMuterUIDs(post.UID)
+	if err != nil {
+		return err
+	}
+
+	return job.timelineStore.PushPost(uint64(post.ID), followerIDs, muterUIDs)
+}
+
+func (job *OutboxDispatchJob) deliverQuote(event models.OutboxEvent) error {
+
+	post, err := job.postStore.GetPost(event.AggregateID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return job.postStore.SaveQuoteForward(post)
+}
+
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := time.Duration(consts.OUTBOX_RETRY_BASE_DELAY) * time.Second
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= consts.OUTBOX_RETRY_MAX_DELAY*time.Second {
+			return consts.OUTBOX_RETRY_MAX_DELAY * time.Second
+		}
+	}
+	return delay
+}
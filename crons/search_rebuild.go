@@ -0,0 +1,176 @@
+package crons
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+	"github.com/mehakhanaa/complex-micro-blog/searchers"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type SearchRebuildJob struct {
+	logger              *logrus.Logger
+	searchIndexStore    *stores.SearchIndexStore
+	postStore           *stores.PostStore
+	outboxStore         *stores.OutboxStore
+	searchServiceClient search.SearchEngineClient
+}
+
+func NewSearchRebuildJob(logger *logrus.Logger, storeFactory *stores.Factory, searchServiceClient search.SearchEngineClient) *SearchRebuildJob {
+	return &SearchRebuildJob{
+		logger:              logger,
+		searchIndexStore:    storeFactory.NewSearchIndexStore(),
+		postStore:           storeFactory.NewPostStore(),
+		outboxStore:         storeFactory.NewOutboxStore(),
+		searchServiceClient: searchServiceClient,
+	}
+}
+
+func (job *SearchRebuildJob) Run() {
+	job.logger.Debugln("Search rebuild job init...")
+
+	progress, err := job.searchIndexStore.GetRebuildProgress()
+	if err != nil {
+		job.logger.Errorln("Error in search rebuild job:", err)
+		return
+	}
+	if progress.Status != types.SEARCH_REBUILD_STATUS_RUNNING {
+		return
+	}
+
+	token := uuid.New().String()
+	acquired, err := job.searchIndexStore.AcquireRebuildLock(token)
+	if err != nil {
+		job.logger.Errorln("Error in search rebuild job:", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+	defer func() {
+		err := job.searchIndexStore.ReleaseRebuildLock(token)
+		if err != nil {
+			job.logger.Errorln("Error in search rebuild job:", err)
+		}
+	}()
+
+	job.logger.Infoln("Search index rebuild running from post", progress.LastID, "indexed", progress.Indexed, "of", progress.Total)
+
+	for {
+		indexed, err := job.indexBatch(progress)
+		if err != nil {
+			job.logger.Errorln("Search index rebuild failed after post", progress.LastID, err)
+			progress.Status = types.SEARCH_REBUILD_STATUS_FAILED
+			progress.Error = err.Error()
+			err = job.searchIndexStore.SaveRebuildProgress(progress)
+			if err != nil {
+				job.logger.Errorln("Error in search rebuild job:", err)
+			}
+			return
+		}
+
+		if indexed == 0 {
+			progress.Status = types.SEARCH_REBUILD_STATUS_COMPLETED
+			err = job.searchIndexStore.SaveRebuildProgress(progress)
+			if err != nil {
+				job.logger.Errorln("Error in search rebuild job:", err)
+				return
+			}
+			job.logger.Infoln("Search index rebuild completed, indexed", progress.Indexed, "posts")
+			return
+		}
+
+		err = job.searchIndexStore.SaveRebuildProgress(progress)
+		if err != nil {
+			job.logger.Errorln("Error in search rebuild job:", err)
+			return
+		}
+		job.logger.Debugln("Search index rebuild progress:", progress.Indexed, "of", progress.Total)
+
+		refreshed, err := job.searchIndexStore.RefreshRebuildLock(token)
+		if err != nil {
+			job.logger.Errorln("Error in search rebuild job:", err)
+			return
+		}
+		if !refreshed {
+			job.logger.Warnln("Search index rebuild lock lost at post", progress.LastID)
+			return
+		}
+	}
+}
+
+func (job *SearchRebuildJob) indexBatch(progress *types.SearchRebuildProgress) (int, error) {
+
+	posts, err := job.postStore.GetPostsAfterID(progress.LastID, consts.SEARCH_REBUILD_BATCH_SIZE)
+	if err != nil {
+		return 0, err
+	}
+	if len(posts) == 0 {
+		return 0, nil
+	}
+
+	requests := make([]*search.CreatePostIndexRequest, len(posts))
+	for index, post := range posts {
+		requests[index] = searchers.NewPostIndexRequest(post)
+	}
+
+	resp, err := job.searchServiceClient.BulkCreatePostIndex(context.Background(), &search.BulkCreatePostIndexRequest{
+		Posts: requests,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if resp.Code != 0 {
+		return 0, fmt.Errorf("search service returned code %d", resp.Code)
+	}
+
+	err = job.reconcileBatch(posts)
+	if err != nil {
+		return 0, err
+	}
+
+	progress.LastID = uint64(posts[len(posts)-1].ID)
+	progress.Indexed += uint64(len(posts))
+	progress.Error = ""
+
+	return len(posts), nil
+}
+
+func (job *SearchRebuildJob) reconcileBatch(posts []models.PostInfo) error {
+
+	postIDs := make([]uint64, len(posts))
+	for index, post := range posts {
+		postIDs[index] = uint64(post.ID)
+	}
+
+	updatedAts, err := job.postStore.GetPostUpdatedAts(postIDs)
+	if err != nil {
+		return err
+	}
+
+	var deletedIDs, changedIDs []uint64
+	for _, post := range posts {
+		updatedAt, ok := updatedAts[uint64(post.ID)]
+		if !ok {
+			deletedIDs = append(deletedIDs, uint64(post.ID))
+			continue
+		}
+		if !updatedAt.Equal(post.UpdatedAt) {
+			changedIDs = append(changedIDs, uint64(post.ID))
+		}
+	}
+
+	err = job.outboxStore.CreateOutboxEvents(types.OUTBOX_EVENT_POST_INDEX_DELETE, deletedIDs...)
+	if err != nil {
+		return err
+	}
+
+	return job.outboxStore.CreateOutboxEvents(types.OUTBOX_EVENT_POST_INDEX_UPDATE, changedIDs...)
+}
@@ -0,0 +1,236 @@
+package crons
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type trendingActivity struct {
+	PostID     uint64
+	Weight     float64
+	OccurredAt time.Time
+}
+
+type TrendingJob struct {
+	logger *logrus.Logger
+	db     *gorm.DB
+	rds    *redis.Client
+	mongo  *mongo.Client
+}
+
+func NewTrendingJob(logger *logrus.Logger, db *gorm.DB, rds *redis.Client, mongoClient *mongo.Client) *TrendingJob {
+	return &TrendingJob{
+		logger: logger,
+		db:     db,
+		rds:    rds,
+		mongo:  mongoClient,
+	}
+}
+
+func (job *TrendingJob) Run() {
+	job.logger.Debugln("Trending job init......")
+
+	now := time.Now()
+
+	var longestWindow time.Duration
+	for _, window := range consts.TRENDING_WINDOWS {
+		if window > longestWindow {
+			longestWindow = window
+		}
+	}
+	since := now.Add(-longestWindow)
+
+	var activities []trendingActivity
+
+	likes, err := job.loadMongoActivities(consts.POST_LIKE_COLLECTION, "liked_at", consts.TRENDING_LIKE_WEIGHT, since)
+	if err != nil {
+		job.logger.Errorln("Error in trending job:", err)
+		return
+	}
+	activities = append(activities, likes...)
+
+	favourites, err := job.loadMongoActivities(consts.POST_FAVORITE_COLLECTION, "favourited_at", consts.TRENDING_FAVOURITE_WEIGHT, since)
+	if err != nil {
+		job.logger.Errorln("Error in trending job:", err)
+		return
+	}
+	activities = append(activities, favourites...)
+
+	comments, err := job.loadCommentActivities(since)
+	if err != nil {
+		job.logger.Errorln("Error in trending job:", err)
+		return
+	}
+	activities = append(activities, comments...)
+
+	for name, window := range consts.TRENDING_WINDOWS {
+		postScores := scoreTrendingActivities(activities, now, window)
+
+		tagScores, err := job.scoreTrendingTags(postScores)
+		if err != nil {
+			job.logger.Errorln("Error in trending job:", err)
+			continue
+		}
+
+		err = job.saveRanking(consts.REDIS_TRENDING_POST+":"+name, postScores)
+		if err != nil {
+			job.logger.Errorln("Error in trending job:", err)
+			continue
+		}
+
+		err = job.saveRanking(consts.REDIS_TRENDING_TAG+":"+name, tagScores)
+		if err != nil {
+			job.logger.Errorln("Error in trending job:", err)
+			continue
+		}
+	}
+
+	job.logger.Debugln("Trending job done")
+}
+
+func (job *TrendingJob) loadMongoActivities(collectionName, timeField string, weight float64, since time.Time) ([]trendingActivity, error) {
+	collection := job.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(collectionName)
+	filter := bson.D{{Key: timeField, Value: bson.D{{Key: "$gte", Value: since}}}}
+	projection := bson.D{{Key: "post_id", Value: 1}, {Key: timeField, Value: 1}}
+	ctx := context.Background()
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var activities []trendingActivity
+	for cursor.Next(ctx) {
+		var record bson.M
+		err = cursor.Decode(&record)
+		if err != nil {
+			return nil, err
+		}
+
+		postID, ok := record["post_id"].(int64)
+		if !ok {
+			continue
+		}
+		occurredAt, ok := record[timeField].(primitive.DateTime)
+		if !ok {
+			continue
+		}
+
+		activities = append(activities, trendingActivity{
+			PostID:     uint64(postID),
+			Weight:     weight,
+			OccurredAt: occurredAt.Time(),
+		})
+	}
+
+	return activities, cursor.Err()
+}
+
+func (job *TrendingJob) loadCommentActivities(since time.Time) ([]trendingActivity, error) {
+	var comments []models.CommentInfo
+	result := job.db.Select("post_id", "created_at").Where("created_at >= ?", since).Find(&comments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	activities := make([]trendingActivity, len(comments))
+	for index, comment := range comments {
+		activities[index] = trendingActivity{
+			PostID:     comment.PostID,
+			Weight:     consts.TRENDING_COMMENT_WEIGHT,
+			OccurredAt: comment.CreatedAt,
+		}
+	}
+
+	return activities, nil
+}
+
+func scoreTrendingActivities(activities []trendingActivity, now time.Time, window time.Duration) map[string]float64 {
+	halfLife := float64(window) / consts.TRENDING_HALF_LIFE_RATIO
+	since := now.Add(-window)
+
+	scores := make(map[string]float64)
+	for _, activity := range activities {
+		if activity.OccurredAt.Before(since) {
+			continue
+		}
+		age := float64(now.Sub(activity.OccurredAt))
+		if age < 0 {
+			age = 0
+		}
+		scores[strconv.FormatUint(activity.PostID, 10)] += activity.Weight * math.Exp2(-age/halfLife)
+	}
+
+	return scores
+}
+
+func (job *TrendingJob) scoreTrendingTags(postScores map[string]float64) (map[string]float64, error) {
+	tagScores := make(map[string]float64)
+	if len(postScores) == 0 {
+		return tagScores, nil
+	}
+
+	postIDs := make([]string, 0, len(postScores))
+	for postID := range postScores {
+		postIDs = append(postIDs, postID)
+	}
+
+	privateUIDs := job.db.Model(&models.UserInfo{}).Select("id").Where("is_private = ?", true)
+	privatePostIDs := job.db.Model(&models.PostInfo{}).Select("id").Where("uid IN (?)", privateUIDs)
+
+	var entities []models.ContentEntity
+	result := job.db.Select("source_id", "text").
+		Where("source_type = ? AND type = ? AND source_id IN ?", types.CONTENT_SOURCE_POST, types.CONTENT_ENTITY_HASHTAG, postIDs).
+		Where("source_id NOT IN (?)", privatePostIDs).
+		Find(&entities)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	seen := make(map[string]bool)
+	for _, entity := range entities {
+		postID := strconv.FormatUint(entity.SourceID, 10)
+		if seen[postID+"#"+entity.Text] {
+			continue
+		}
+		seen[postID+"#"+entity.Text] = true
+		tagScores[entity.Text] += postScores[postID]
+	}
+
+	return tagScores, nil
+}
+
+func (job *TrendingJob) saveRanking(key string, scores map[string]float64) error {
+	ctx := context.Background()
+
+	members := make([]redis.Z, 0, len(scores))
+	for member, score := range scores {
+		members = append(members, redis.Z{Score: score, Member: member})
+	}
+
+	tx := job.rds.TxPipeline()
+	tx.Del(ctx, key)
+	if len(members) > 0 {
+		tx.ZAdd(ctx, key, members...)
+		tx.ZRemRangeByRank(ctx, key, 0, -(consts.TRENDING_LIST_MAX_LENGTH + 1))
+		tx.Expire(ctx, key, consts.TRENDING_EXPIRE_TIME)
+	}
+
+	_, err := tx.Exec(ctx)
+	return err
+}
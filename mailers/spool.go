@@ -0,0 +1,58 @@
+package mailers
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SpoolMailer struct {
+	from string
+	dir  string
+}
+
+func NewSpoolMailer(from string, dir string) (*SpoolMailer, error) {
+
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpoolMailer{from: from, dir: dir}, nil
+}
+
+func (mailer *SpoolMailer) Send(message Message) error {
+
+	now := time.Now()
+
+	var sb strings.Builder
+	sb.WriteString("From: ")
+	sb.WriteString(mailer.from)
+	sb.WriteString("\r\nTo: ")
+	sb.WriteString(message.To)
+	sb.WriteString("\r\nSubject: ")
+	sb.WriteString(message.Subject)
+	sb.WriteString("\r\nDate: ")
+	sb.WriteString(now.Format(time.RFC1123Z))
+	sb.WriteString("\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	sb.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	sb.WriteString("\r\n")
+
+	var fileName strings.Builder
+	fileName.WriteString(strconv.FormatInt(now.UnixMilli(), 10))
+	fileName.WriteRune('_')
+	fileName.WriteString(uuid.New().String())
+	fileName.WriteString(".eml")
+
+	tmpPath := filepath.Join(mailer.dir, "."+fileName.String())
+	err := os.WriteFile(tmpPath, []byte(sb.String()), 0o640)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, filepath.Join(mailer.dir, fileName.String()))
+}
@@ -25,11 +25,15 @@ import (
 	"github.com/mehakhanaa/complex-micro-blog/controllers"
 	"github.com/mehakhanaa/complex-micro-blog/crons"
 	"github.com/mehakhanaa/complex-micro-blog/loggers"
+	"github.com/mehakhanaa/complex-micro-blog/mailers"
 	"github.com/mehakhanaa/complex-micro-blog/middlewares"
 	"github.com/mehakhanaa/complex-micro-blog/models"
 	search "github.com/mehakhanaa/complex-micro-blog/proto"
+	"github.com/mehakhanaa/complex-micro-blog/searchers"
 	"github.com/mehakhanaa/complex-micro-blog/services"
 	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/utils/encryptors"
+	"github.com/mehakhanaa/complex-micro-blog/utils/keys"
 )
 
 var (
@@ -40,6 +44,9 @@ var (
 	mongoClient         *mongo.Client
 	searchSeviceConn    *grpc.ClientConn
 	searchServiceClient search.SearchEngineClient
+	keySet              *keys.KeySet
+	secretBox           *encryptors.SecretBox
+	mailer              mailers.Mailer
 	storeFactory        *stores.Factory
 	controllerFactory   *controllers.Factory
 	middlewareFactory   *middlewares.Factory
@@ -123,24 +130,53 @@ func init() {
 	}
 	logger.Debugln("MongoDB Connected")
 
-	searchSeviceConn, err = grpc.Dial(fmt.Sprintf("%s:%d", cfg.SearchService.Host, cfg.SearchService.Port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	logger.Debugln("Init search backend:", cfg.SearchService.Backend)
+	switch cfg.SearchService.Backend {
+	case consts.SEARCH_BACKEND_GRPC, "":
+		searchSeviceConn, err = grpc.Dial(fmt.Sprintf("%s:%d", cfg.SearchService.Host, cfg.SearchService.Port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			logger.Panicln("grpc error", err.Error())
+		}
+		searchServiceClient = search.NewSearchEngineClient(searchSeviceConn)
+	case consts.SEARCH_BACKEND_LOCAL:
+		if cfg.Env.Type == "production" {
+			logger.Panicln("local search backend is for development only and cannot run in production with prefork enabled")
+		}
+		searchServiceClient, err = searchers.NewLocalSearchEngine(db)
+		if err != nil {
+			logger.Panicln("Error in local search index", err.Error())
+		}
+	default:
+		logger.Panicln("unknown search backend", cfg.SearchService.Backend)
+	}
+
+	keySet, err = keys.NewKeySet(cfg)
+	if err != nil {
+		logger.Panicln("Error in token keys", err.Error())
+	}
+
+	secretBox, err = encryptors.NewSecretBox(cfg.TwoFactor.SecretKey)
+	if err != nil {
+		logger.Panicln("Error in two-factor secret key", err.Error())
+	}
+
+	mailer, err = mailers.NewSpoolMailer(cfg.Mail.From, cfg.Mail.SpoolDir)
 	if err != nil {
-		logger.Panicln("grpc error", err.Error())
+		logger.Panicln("Error in mailer", err.Error())
 	}
-	searchServiceClient = search.NewSearchEngineClient(searchSeviceConn)
 
 	storeFactory = stores.NewFactory(db, redisClient, mongoClient, searchServiceClient)
 
 	controllerFactory = controllers.NewFactory(
-		services.NewFactory(storeFactory),
+		services.NewFactory(storeFactory, keySet, secretBox, mailer, cfg.Mail.BaseURL),
 	)
 
-	middlewareFactory = middlewares.NewFactory(storeFactory)
+	middlewareFactory = middlewares.NewFactory(storeFactory, keySet)
 }
 
 func main() {
 
-	crons.InitJobs(logger, db, redisClient)
+	crons.InitJobs(logger, db, redisClient, mongoClient, storeFactory, searchServiceClient)
 
 	var fiberConfig fiber.Config
 
@@ -156,10 +192,19 @@ func main() {
 		Format: "[${time}][${latency}][${status}][${method}] ${path}\n",
 	}))
 	app.Use(compress.New(compress.Config{
+		Next: func(ctx *fiber.Ctx) bool {
+			return ctx.Path() == "/api/stream"
+		},
 		Level: cfg.Compress.Level,
 	}))
 
+	if cfg.RateLimit.Enabled {
+		rateLimitMiddleware := middlewareFactory.NewRateLimitMiddleware(cfg.RateLimit.Default, cfg.RateLimit.Rules)
+		app.Use("/api", rateLimitMiddleware.NewMiddleware())
+	}
+
 	authMiddleware := middlewareFactory.NewTokenAuthMiddleware()
+	authorityMiddleware := middlewareFactory.NewAuthorityMiddleware()
 
 	resource := app.Group("/resources")
 
@@ -171,6 +216,9 @@ func main() {
 		Compress: true,
 	})
 
+	keyController := controllerFactory.NewKeyController()
+	app.Get("/.well-known/jwks.json", keyController.NewJWKSHandler())
+
 	api := app.Group("/api")
 
 	userController := controllerFactory.NewUserController()
@@ -178,13 +226,33 @@ func main() {
 	user.Get("/profile", userController.NewProfileHandler())
 	user.Post("/register", userController.NewRegisterHandler())
 	user.Post("/login", userController.NewLoginHandler())
+	user.Post("/login/2fa", userController.NewTwoFactorLoginHandler())
+	user.Post("/refresh", userController.NewRefreshTokenHandler())
+	user.Post("/logout", authMiddleware.NewMiddleware(), userController.NewLogoutHandler())
+	user.Get("/sessions", authMiddleware.NewMiddleware(), userController.NewSessionListHandler())
+	user.Post("/sessions/revoke", authMiddleware.NewMiddleware(), userController.NewRevokeSessionHandler())
+	user.Get("/login-history", authMiddleware.NewMiddleware(), userController.NewLoginHistoryHandler())
+	user.Post("/unlock/request", userController.NewRequestUnlockHandler())
+	user.Post("/unlock", userController.NewUnlockHandler())
+	user.Post("/email", authMiddleware.NewMiddleware(), userController.NewUpdateEmailHandler())
+	user.Post("/email/resend", authMiddleware.NewMiddleware(), userController.NewResendEmailVerificationHandler())
+	user.Get("/email/verify", userController.NewVerifyEmailHandler())
+	user.Post("/password/forgot", userController.NewForgotPasswordHandler())
+	user.Post("/password/reset", userController.NewResetPasswordHandler())
+	user.Post("/2fa/enroll", authMiddleware.NewMiddleware(), userController.NewEnrollTwoFactorHandler())
+	user.Post("/2fa/confirm", authMiddleware.NewMiddleware(), userController.NewConfirmTwoFactorHandler())
+	user.Post("/2fa/disable", authMiddleware.NewMiddleware(), userController.NewDisableTwoFactorHandler())
+	user.Post("/2fa/recovery-codes", authMiddleware.NewMiddleware(), userController.NewRegenerateRecoveryCodesHandler())
 	user.Post("/upload-avatar", authMiddleware.NewMiddleware(), userController.NewUploadAvatarHandler())
 	user.Post("/update-psw", userController.NewUpdatePasswordHandler())
 	user.Post("/edit", authMiddleware.NewMiddleware(), userController.NewUpdateProfileHandler())
 
-	postController := controllerFactory.NewPostController(searchServiceClient)
+	trendingController := controllerFactory.NewTrendingController()
+
+	postController := controllerFactory.NewPostController()
 	post := api.Group("/post")
-	post.Get("/list", postController.NewPostListHandler(storeFactory.NewUserStore()))
+	post.Get("/trending", authMiddleware.NewOptionalMiddleware(), trendingController.NewTrendingPostListHandler())
+	post.Get("/list", authMiddleware.NewOptionalMiddleware(), postController.NewPostListHandler(storeFactory.NewUserStore()))
 	post.Get("/user-status", authMiddleware.NewMiddleware(), postController.NewPostUserStatusHandler())
 	post.Post("/new", authMiddleware.NewMiddleware(), postController.NewCreatePostHandler())
 	post.Post("/upload-img", authMiddleware.NewMiddleware(), postController.NewUploadPostImageHandler())
@@ -192,12 +260,16 @@ func main() {
 	post.Post("/cancel-like", authMiddleware.NewMiddleware(), postController.NewCancelLikePostHandler())
 	post.Post("/favourite", authMiddleware.NewMiddleware(), postController.NewFavouritePostHandler())
 	post.Post("/cancel-favourite", authMiddleware.NewMiddleware(), postController.NewCancelFavouritePostHandler())
-	post.Get("/:post", postController.NewPostDetailHandler())
+	post.Post("/repost", authMiddleware.NewMiddleware(), postController.NewRepostPostHandler())
+	post.Post("/cancel-repost", authMiddleware.NewMiddleware(), postController.NewCancelRepostPostHandler())
+	post.Post("/quote", authMiddleware.NewMiddleware(), postController.NewQuotePostHandler())
+	post.Post("/edit", authMiddleware.NewMiddleware(), postController.NewUpdatePostHandler())
+	post.Get("/:post", authMiddleware.NewOptionalMiddleware(), postController.NewPostDetailHandler())
 	post.Delete("/:post", authMiddleware.NewMiddleware(), postController.NewDeletePostHandler())
 
 	commentController := controllerFactory.NewCommentController()
 	comment := api.Group("/comment")
-	comment.Get("/list", commentController.NewCommentListHandler())
+	comment.Get("/list", authMiddleware.NewOptionalMiddleware(), commentController.NewCommentListHandler())
 	comment.Get("/detail", commentController.NewCommentDetailHandler())
 	comment.Get("/user-status", authMiddleware.NewMiddleware(), commentController.NewCommentUserStatusHandler())
 	comment.Post("/edit", authMiddleware.NewMiddleware(), commentController.NewUpdateCommentHandler())
@@ -213,7 +285,7 @@ func main() {
 
 	replyController := controllerFactory.NewReplyController()
 	reply := api.Group("/reply")
-	reply.Get("/list", replyController.NewGetReplyListHandler())
+	reply.Get("/list", authMiddleware.NewOptionalMiddleware(), replyController.NewGetReplyListHandler())
 	reply.Get("/detail", replyController.NewGetReplyDetailHandler())
 	reply.Post("/new", authMiddleware.NewMiddleware(), replyController.NewCreateReplyHandler(
 		storeFactory.NewCommentStore(),
@@ -224,7 +296,9 @@ func main() {
 
 	searchController := controllerFactory.NewSearchController(searchServiceClient)
 	search := api.Group("/search")
-	search.Get("/post", searchController.NewSearchPostHandler())
+	search.Get("/post", authMiddleware.NewOptionalMiddleware(), searchController.NewSearchPostHandler())
+	search.Get("/user", authMiddleware.NewOptionalMiddleware(), searchController.NewSearchUserHandler())
+	search.Get("/comment", authMiddleware.NewOptionalMiddleware(), searchController.NewSearchCommentHandler())
 
 	followController := controllerFactory.NewFollowController()
 	follow := api.Group("/follow")
@@ -234,6 +308,76 @@ func main() {
 	follow.Get("/list-count", followController.NewFollowCountHandler())
 	follow.Get("/follower-list", followController.NewFollowerListHandler())
 	follow.Get("/follower-list-count", followController.NewFollowerCountHandler())
+	follow.Get("/requests", authMiddleware.NewMiddleware(), followController.NewFollowRequestListHandler())
+	follow.Post("/requests/approve", authMiddleware.NewMiddleware(), followController.NewApproveFollowRequestHandler())
+	follow.Post("/requests/reject", authMiddleware.NewMiddleware(), followController.NewRejectFollowRequestHandler())
+
+	notificationController := controllerFactory.NewNotificationController()
+	notification := api.Group("/notification")
+	notification.Get("/list", authMiddleware.NewMiddleware(), notificationController.NewNotificationListHandler())
+	notification.Get("/unread-count", authMiddleware.NewMiddleware(), notificationController.NewUnreadCountHandler())
+	notification.Post("/read", authMiddleware.NewMiddleware(), notificationController.NewReadNotificationHandler())
+	notification.Post("/read-all", authMiddleware.NewMiddleware(), notificationController.NewReadAllNotificationsHandler())
+
+	relationController := controllerFactory.NewRelationController()
+	relation := api.Group("/relation")
+	relation.Post("/block", authMiddleware.NewMiddleware(), relationController.NewBlockHandler())
+	relation.Post("/unblock", authMiddleware.NewMiddleware(), relationController.NewUnblockHandler())
+	relation.Post("/mute", authMiddleware.NewMiddleware(), relationController.NewMuteHandler())
+	relation.Post("/unmute", authMiddleware.NewMiddleware(), relationController.NewUnmuteHandler())
+	relation.Get("/blocks", authMiddleware.NewMiddleware(), relationController.NewBlockListHandler())
+	relation.Get("/mutes", authMiddleware.NewMiddleware(), relationController.NewMuteListHandler())
+
+	tagController := controllerFactory.NewTagController()
+	tag := api.Group("/tag")
+	tag.Get("/posts", authMiddleware.NewOptionalMiddleware(), tagController.NewTagPostListHandler())
+	tag.Get("/trending", trendingController.NewTrendingTagListHandler())
+
+	accountController := controllerFactory.NewAccountController()
+	account := api.Group("/account")
+	account.Get("/export", authMiddleware.NewMiddleware(), accountController.NewExportHandler())
+	account.Get("/delete", authMiddleware.NewMiddleware(), accountController.NewDeletionStatusHandler())
+	account.Post("/delete", authMiddleware.NewMiddleware(), accountController.NewScheduleDeletionHandler())
+	account.Post("/delete/cancel", authMiddleware.NewMiddleware(), accountController.NewCancelDeletionHandler())
+
+	streamController := controllerFactory.NewStreamController()
+	api.Get("/stream", authMiddleware.NewStreamMiddleware(), streamController.NewStreamHandler())
+
+	admin := api.Group("/admin")
+	admin.Post(
+		"/user/unlock",
+		authMiddleware.NewMiddleware(),
+		authorityMiddleware.NewMiddleware(consts.AUTHORITY_ADMIN),
+		userController.NewAdminUnlockHandler(),
+	)
+
+	outboxController := controllerFactory.NewOutboxController()
+	admin.Get(
+		"/outbox",
+		authMiddleware.NewMiddleware(),
+		authorityMiddleware.NewMiddleware(consts.AUTHORITY_ADMIN),
+		outboxController.NewOutboxLagHandler(),
+	)
+
+	searchIndexController := controllerFactory.NewSearchIndexController(searchServiceClient)
+	admin.Get(
+		"/search/rebuild",
+		authMiddleware.NewMiddleware(),
+		authorityMiddleware.NewMiddleware(consts.AUTHORITY_ADMIN),
+		searchIndexController.NewRebuildProgressHandler(),
+	)
+	admin.Post(
+		"/search/rebuild",
+		authMiddleware.NewMiddleware(),
+		authorityMiddleware.NewMiddleware(consts.AUTHORITY_ADMIN),
+		searchIndexController.NewStartRebuildHandler(),
+	)
+	admin.Post(
+		"/search/verify",
+		authMiddleware.NewMiddleware(),
+		authorityMiddleware.NewMiddleware(consts.AUTHORITY_ADMIN),
+		searchIndexController.NewVerifyHandler(),
+	)
 
 	log.Fatal(app.Listen(fmt.Sprintf("%s:%d", cfg.Database.Host, cfg.Server.Port)))
 }
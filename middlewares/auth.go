@@ -8,55 +8,73 @@ import (
 
 	"github.com/mehakhanaa/complex-micro-blog/consts"
 	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/keys"
 	"github.com/mehakhanaa/complex-micro-blog/utils/parsers"
 	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
 )
 
 type TokenAuthMiddleware struct {
 	userStore *stores.UserStore
+	keySet    *keys.KeySet
 }
 
 func (factory *Factory) NewTokenAuthMiddleware() *TokenAuthMiddleware {
-	return &TokenAuthMiddleware{userStore: factory.store.NewUserStore()}
+	return &TokenAuthMiddleware{
+		userStore: factory.store.NewUserStore(),
+		keySet:    factory.keySet,
+	}
 }
 
 func (middleware *TokenAuthMiddleware) NewMiddleware() fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 
-		token := ctx.Get("Authorization")
-		if token == "" {
-			return ctx.Status(200).JSON(
-				serializers.NewResponse(consts.PARAMETER_ERROR, "bearer token is required"),
-			)
-		}
-		if len(token) < 7 || token[:7] != "Bearer " {
+		claims, code, err := middleware.authenticate(ctx.Get("Authorization"))
+		if err != nil {
 			return ctx.Status(200).JSON(
-				serializers.NewResponse(consts.PARAMETER_ERROR, "bearer token is invalid"),
+				serializers.NewResponse(code, err.Error()),
 			)
 		}
-		token = token[7:]
 
-		claims, err := parsers.ParseToken(token)
-		if errors.Is(err, jwt.ErrTokenExpired) {
-			return ctx.Status(200).JSON(
-				serializers.NewResponse(consts.AUTH_ERROR, "bearer token is expired"),
-			)
+		ctx.Locals("claims", claims)
+
+		return ctx.Next()
+	}
+}
+
+func (middleware *TokenAuthMiddleware) NewOptionalMiddleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		token := ctx.Get("Authorization")
+		if token == "" {
+			return ctx.Next()
 		}
+
+		claims, code, err := middleware.authenticate(token)
 		if err != nil {
 			return ctx.Status(200).JSON(
-				serializers.NewResponse(consts.AUTH_ERROR, err.Error()),
+				serializers.NewResponse(code, err.Error()),
 			)
 		}
 
-		isAvaliable, err := middleware.userStore.IsUserTokenAvaliable(token)
-		if err != nil {
-			return ctx.Status(200).JSON(
-				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
-			)
+		ctx.Locals("claims", claims)
+
+		return ctx.Next()
+	}
+}
+
+func (middleware *TokenAuthMiddleware) NewStreamMiddleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		token := ctx.Get("Authorization")
+		if token == "" && ctx.Query("token") != "" {
+			token = "Bearer " + ctx.Query("token")
 		}
-		if !isAvaliable {
+
+		claims, code, err := middleware.authenticate(token)
+		if err != nil {
 			return ctx.Status(200).JSON(
-				serializers.NewResponse(consts.AUTH_ERROR, "bearer token is not avaliable"),
+				serializers.NewResponse(code, err.Error()),
 			)
 		}
 
@@ -65,3 +83,32 @@ func (middleware *TokenAuthMiddleware) NewMiddleware() fiber.Handler {
 		return ctx.Next()
 	}
 }
+
+func (middleware *TokenAuthMiddleware) authenticate(token string) (*types.BearerTokenClaims, serializers.ResponseCode, error) {
+
+	if token == "" {
+		return nil, consts.PARAMETER_ERROR, errors.New("bearer token is required")
+	}
+	if len(token) < 7 || token[:7] != "Bearer " {
+		return nil, consts.PARAMETER_ERROR, errors.New("bearer token is invalid")
+	}
+	token = token[7:]
+
+	claims, err := parsers.ParseToken(middleware.keySet, token)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, consts.AUTH_ERROR, errors.New("bearer token is expired")
+	}
+	if err != nil {
+		return nil, consts.AUTH_ERROR, err
+	}
+
+	isAvaliable, err := middleware.userStore.IsUserTokenAvaliable(claims)
+	if err != nil {
+		return nil, consts.SERVER_ERROR, err
+	}
+	if !isAvaliable {
+		return nil, consts.AUTH_ERROR, errors.New("bearer token is not avaliable")
+	}
+
+	return claims, consts.SUCCESS, nil
+}
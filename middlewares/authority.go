@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type AuthorityMiddleware struct {
+	userStore *stores.UserStore
+}
+
+func (factory *Factory) NewAuthorityMiddleware() *AuthorityMiddleware {
+	return &AuthorityMiddleware{userStore: factory.store.NewUserStore()}
+}
+
+func (middleware *AuthorityMiddleware) NewMiddleware(authority uint64) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		claims, ok := ctx.Locals("claims").(*types.BearerTokenClaims)
+		if !ok {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.AUTH_ERROR, "bearer token is required"),
+			)
+		}
+
+		user, err := middleware.userStore.GetUserByUID(claims.UID)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+		if user.Authority < authority {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.FORBIDDEN_ERROR, "permission denied"),
+			)
+		}
+
+		return ctx.Next()
+	}
+}
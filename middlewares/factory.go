@@ -1,11 +1,15 @@
 package middlewares
 
-import "github.com/mehakhanaa/complex-micro-blog/stores"
+import (
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/utils/keys"
+)
 
 type Factory struct {
-	store *stores.Factory
+	store  *stores.Factory
+	keySet *keys.KeySet
 }
 
-func NewFactory(store *stores.Factory) *Factory {
-	return &Factory{store: store}
+func NewFactory(store *stores.Factory, keySet *keys.KeySet) *Factory {
+	return &Factory{store: store, keySet: keySet}
 }
@@ -0,0 +1,105 @@
+package middlewares
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/mehakhanaa/complex-micro-blog/configs"
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/utils/keys"
+	"github.com/mehakhanaa/complex-micro-blog/utils/parsers"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type RateLimitMiddleware struct {
+	rateLimitStore *stores.RateLimitStore
+	keySet         *keys.KeySet
+	defaultRule    configs.RateLimitRule
+	rules          []configs.RateLimitRule
+}
+
+func (factory *Factory) NewRateLimitMiddleware(defaultRule configs.RateLimitRule, rules []configs.RateLimitRule) *RateLimitMiddleware {
+
+	sortedRules := make([]configs.RateLimitRule, len(rules))
+	copy(sortedRules, rules)
+	sort.SliceStable(sortedRules, func(i, j int) bool {
+		return len(sortedRules[i].Path) > len(sortedRules[j].Path)
+	})
+
+	return &RateLimitMiddleware{
+		rateLimitStore: factory.store.NewRateLimitStore(),
+		keySet:         factory.keySet,
+		defaultRule:    defaultRule,
+		rules:          sortedRules,
+	}
+}
+
+func (middleware *RateLimitMiddleware) NewMiddleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		rule := middleware.matchRule(ctx.Path())
+		if rule.Limit <= 0 || rule.Window <= 0 {
+			return ctx.Next()
+		}
+
+		var sb strings.Builder
+		sb.WriteString(consts.REDIS_RATE_LIMIT)
+		sb.WriteRune(':')
+		sb.WriteString(rule.Path)
+		sb.WriteRune(':')
+		sb.WriteString(middleware.subject(ctx))
+
+		allowed, remaining, retryAfter, err := middleware.rateLimitStore.Allow(
+			sb.String(),
+			rule.Limit,
+			time.Duration(rule.Window)*time.Second,
+		)
+		if err != nil {
+			return ctx.Status(200).JSON(
+				serializers.NewResponse(consts.SERVER_ERROR, err.Error()),
+			)
+		}
+
+		ctx.Set("X-RateLimit-Limit", strconv.Itoa(rule.Limit))
+		ctx.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !allowed {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+			return ctx.Status(fiber.StatusTooManyRequests).JSON(
+				serializers.NewResponse(consts.RATE_LIMIT_ERROR, "too many requests"),
+			)
+		}
+
+		return ctx.Next()
+	}
+}
+
+func (middleware *RateLimitMiddleware) matchRule(path string) configs.RateLimitRule {
+
+	for _, rule := range middleware.rules {
+		if strings.HasPrefix(path, rule.Path) {
+			return rule
+		}
+	}
+
+	return middleware.defaultRule
+}
+
+func (middleware *RateLimitMiddleware) subject(ctx *fiber.Ctx) string {
+
+	token := ctx.Get("Authorization")
+	if len(token) > 7 && token[:7] == "Bearer " {
+		claims, err := parsers.ParseToken(middleware.keySet, token[7:])
+		if err == nil {
+			return "UID:" + strconv.FormatUint(claims.UID, 10)
+		}
+	}
+
+	return "IP:" + ctx.IP()
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AccountDeletion struct {
+	gorm.Model
+	UID         uint64    `gorm:"unique;column:uid"`
+	ScheduledAt time.Time `gorm:"index;column:scheduled_at"`
+}
+
+type PostLikeRecord struct {
+	UserID  int64     `bson:"uid"`
+	PostID  int64     `bson:"post_id"`
+	LikedAt time.Time `bson:"liked_at"`
+}
+
+type PostFavouriteRecord struct {
+	UserID       int64     `bson:"uid"`
+	PostID       int64     `bson:"post_id"`
+	FavouritedAt time.Time `bson:"favourited_at"`
+}
+
+type CommentRateRecord struct {
+	UserID    uint64    `bson:"uid"`
+	CommentID uint64    `bson:"comment_id"`
+	Rate      string    `bson:"rate"`
+	RatedAt   time.Time `bson:"rated_at"`
+}
+
+type AccountArchive struct {
+	User         UserInfo
+	AuthInfo     UserAuthInfo
+	Posts        []PostInfo
+	Comments     []CommentInfo
+	Replies      []ReplyInfo
+	Likes        []PostLikeRecord
+	Favourites   []PostFavouriteRecord
+	CommentRates []CommentRateRecord
+	Forwards     []ForwardInfo
+	Followings   []FollowInfo
+	Followers    []FollowInfo
+	LoginLogs    []UserLoginLog
+}
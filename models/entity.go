@@ -0,0 +1,18 @@
+package models
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type ContentEntity struct {
+	gorm.Model
+	SourceType types.ContentSourceType `gorm:"column:source_type;index:idx_content_entity_source"`
+	SourceID   uint64                  `gorm:"column:source_id;index:idx_content_entity_source"`
+	Type       types.ContentEntityType `gorm:"column:type"`
+	Text       string                  `gorm:"column:text;index"`
+	MentionUID *uint64                 `gorm:"column:mention_uid;index"`
+	Offset     int                     `gorm:"column:text_offset"`
+	Length     int                     `gorm:"column:text_length"`
+}
@@ -9,3 +9,9 @@ type FollowInfo struct {
 	FollowedID uint64    `bson:"followed_id"`
 	FollowedAt time.Time `bson:"followed_at"`
 }
+
+type FollowRequestInfo struct {
+	UserID      uint64    `bson:"uid"`
+	FollowedID  uint64    `bson:"followed_id"`
+	RequestedAt time.Time `bson:"requested_at"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+type ForwardInfo struct {
+	UserID      int64     `bson:"uid"`
+	PostID      int64     `bson:"post_id"`
+	QuotePostID *int64    `bson:"quote_post_id"`
+	ForwardedAt time.Time `bson:"forwarded_at"`
+}
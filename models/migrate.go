@@ -8,12 +8,32 @@ func Migrate(db *gorm.DB) error {
 	if err = db.AutoMigrate(&UserInfo{}); err != nil {
 		return err
 	}
+	hasPendingEmail := db.Migrator().HasColumn(&UserAuthInfo{}, "pending_email")
+	if db.Migrator().HasIndex(&UserAuthInfo{}, "idx_user_auth_infos_email") {
+		if err = db.Migrator().DropIndex(&UserAuthInfo{}, "idx_user_auth_infos_email"); err != nil {
+			return err
+		}
+	}
 	if err = db.AutoMigrate(&UserAuthInfo{}); err != nil {
 		return err
 	}
+	if !hasPendingEmail {
+		if err = db.Model(&UserAuthInfo{}).Where("email IS NOT NULL AND email_verified = ?", false).Updates(map[string]interface{}{
+			"pending_email": gorm.Expr("email"),
+			"email":         nil,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	hasLoginReview := db.Migrator().HasColumn(&UserLoginLog{}, "is_flagged")
 	if err = db.AutoMigrate(&UserLoginLog{}); err != nil {
 		return err
 	}
+	if !hasLoginReview {
+		if err = db.Model(&UserLoginLog{}).Where("if_checked = ?", false).UpdateColumn("if_checked", true).Error; err != nil {
+			return err
+		}
+	}
 	if err = db.AutoMigrate(&UserPostStatus{}); err != nil {
 		return err
 	}
@@ -33,5 +53,17 @@ func Migrate(db *gorm.DB) error {
 		return err
 	}
 
+	if err = db.AutoMigrate(&ContentEntity{}); err != nil {
+		return err
+	}
+
+	if err = db.AutoMigrate(&AccountDeletion{}); err != nil {
+		return err
+	}
+
+	if err = db.AutoMigrate(&OutboxEvent{}); err != nil {
+		return err
+	}
+
 	return nil
 }
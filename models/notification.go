@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type NotificationInfo struct {
+	ID         primitive.ObjectID           `bson:"_id,omitempty"`
+	UserID     uint64                       `bson:"uid"`
+	Type       types.NotificationType       `bson:"type"`
+	TargetType types.NotificationTargetType `bson:"target_type"`
+	TargetID   uint64                       `bson:"target_id"`
+	ActorIDs   []uint64                     `bson:"actor_ids"`
+	IsRead     bool                         `bson:"is_read"`
+	CreatedAt  time.Time                    `bson:"created_at"`
+	UpdatedAt  time.Time                    `bson:"updated_at"`
+}
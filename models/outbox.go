@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type OutboxEvent struct {
+	gorm.Model
+	Type          types.OutboxEventType `gorm:"column:type"`
+	AggregateID   uint64                `gorm:"column:aggregate_id"`
+	Attempts      int                   `gorm:"column:attempts;default:0"`
+	NextAttemptAt time.Time             `gorm:"index;column:next_attempt_at"`
+	LastError     string                `gorm:"column:last_error"`
+	DeadAt        *time.Time            `gorm:"index;column:dead_at"`
+}
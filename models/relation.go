@@ -0,0 +1,11 @@
+package models
+
+import (
+	"time"
+)
+
+type RelationInfo struct {
+	UserID    uint64    `bson:"uid"`
+	TargetID  uint64    `bson:"target_id"`
+	CreatedAt time.Time `bson:"created_at"`
+}
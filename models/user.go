@@ -16,14 +16,21 @@ type UserInfo struct {
 	Gender    *string    `gorm:"column:gender"`
 	Authority uint64     `gorm:"default:0;column:authority"`
 	Level     uint64     `gorm:"default:1;column:level"`
+	IsPrivate bool       `gorm:"default:false;column:is_private"`
 }
 
 type UserAuthInfo struct {
 	gorm.Model
-	UID          uint64 `gorm:"unique;column:uid"`
-	UserName     string `gorm:"unique;column:username"`
-	Salt         string `gorm:"column:salt"`
-	PasswordHash string `gorm:"column:psw_hash"`
+	UID           uint64         `gorm:"unique;column:uid"`
+	UserName      string         `gorm:"unique;column:username"`
+	Salt          string         `gorm:"column:salt"`
+	PasswordHash  string         `gorm:"column:psw_hash"`
+	Email         *string        `gorm:"uniqueIndex:idx_user_auth_infos_verified_email,where:email_verified;column:email"`
+	EmailVerified bool           `gorm:"default:false;column:email_verified"`
+	PendingEmail  *string        `gorm:"index;column:pending_email"`
+	TOTPSecret    *string        `gorm:"column:totp_secret"`
+	TOTPEnabled   bool           `gorm:"default:false;column:totp_enabled"`
+	RecoveryCodes pq.StringArray `gorm:"column:recovery_codes;type:text[]"`
 }
 
 type UserLoginLog struct {
@@ -37,6 +44,9 @@ type UserLoginLog struct {
 	Device      string    `gorm:"default:unknown;column:device"`
 	Application string    `gorm:"default:unknown;column:application"`
 	BearerToken string    `gorm:"column:bearer_token"`
+	SessionID   string    `gorm:"index;column:session_id"`
+	IsFlagged   bool      `gorm:"default:false;column:is_flagged"`
+	FlagReason  string    `gorm:"column:flag_reason"`
 }
 
 type UserPostStatus struct {
@@ -10,13 +10,37 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SearchEngine_CreatePostIndex_FullMethodName = "/SearchEngine/CreatePostIndex"
-	SearchEngine_Search_FullMethodName          = "/SearchEngine/Search"
+	SearchEngine_CreatePostIndex_FullMethodName     = "/SearchEngine/CreatePostIndex"
+	SearchEngine_UpdatePostIndex_FullMethodName     = "/SearchEngine/UpdatePostIndex"
+	SearchEngine_DeletePostIndex_FullMethodName     = "/SearchEngine/DeletePostIndex"
+	SearchEngine_BulkCreatePostIndex_FullMethodName = "/SearchEngine/BulkCreatePostIndex"
+	SearchEngine_GetPostIndex_FullMethodName        = "/SearchEngine/GetPostIndex"
+	SearchEngine_Search_FullMethodName              = "/SearchEngine/Search"
+	SearchEngine_CreateUserIndex_FullMethodName     = "/SearchEngine/CreateUserIndex"
+	SearchEngine_UpdateUserIndex_FullMethodName     = "/SearchEngine/UpdateUserIndex"
+	SearchEngine_DeleteUserIndex_FullMethodName     = "/SearchEngine/DeleteUserIndex"
+	SearchEngine_SearchUser_FullMethodName          = "/SearchEngine/SearchUser"
+	SearchEngine_CreateCommentIndex_FullMethodName  = "/SearchEngine/CreateCommentIndex"
+	SearchEngine_UpdateCommentIndex_FullMethodName  = "/SearchEngine/UpdateCommentIndex"
+	SearchEngine_DeleteCommentIndex_FullMethodName  = "/SearchEngine/DeleteCommentIndex"
+	SearchEngine_SearchComment_FullMethodName       = "/SearchEngine/SearchComment"
 )
 
 type SearchEngineClient interface {
 	CreatePostIndex(ctx context.Context, in *CreatePostIndexRequest, opts ...grpc.CallOption) (*CreatePostIndexResponse, error)
+	UpdatePostIndex(ctx context.Context, in *UpdatePostIndexRequest, opts ...grpc.CallOption) (*UpdatePostIndexResponse, error)
+	DeletePostIndex(ctx context.Context, in *DeletePostIndexRequest, opts ...grpc.CallOption) (*DeletePostIndexResponse, error)
+	BulkCreatePostIndex(ctx context.Context, in *BulkCreatePostIndexRequest, opts ...grpc.CallOption) (*BulkCreatePostIndexResponse, error)
+	GetPostIndex(ctx context.Context, in *GetPostIndexRequest, opts ...grpc.CallOption) (*GetPostIndexResponse, error)
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	CreateUserIndex(ctx context.Context, in *UserIndexRequest, opts ...grpc.CallOption) (*UserIndexResponse, error)
+	UpdateUserIndex(ctx context.Context, in *UserIndexRequest, opts ...grpc.CallOption) (*UserIndexResponse, error)
+	DeleteUserIndex(ctx context.Context, in *DeleteUserIndexRequest, opts ...grpc.CallOption) (*DeleteUserIndexResponse, error)
+	SearchUser(ctx context.Context, in *SearchUserRequest, opts ...grpc.CallOption) (*SearchUserResponse, error)
+	CreateCommentIndex(ctx context.Context, in *CommentIndexRequest, opts ...grpc.CallOption) (*CommentIndexResponse, error)
+	UpdateCommentIndex(ctx context.Context, in *CommentIndexRequest, opts ...grpc.CallOption) (*CommentIndexResponse, error)
+	DeleteCommentIndex(ctx context.Context, in *DeleteCommentIndexRequest, opts ...grpc.CallOption) (*DeleteCommentIndexResponse, error)
+	SearchComment(ctx context.Context, in *SearchCommentRequest, opts ...grpc.CallOption) (*SearchCommentResponse, error)
 }
 
 type searchEngineClient struct {
@@ -36,6 +60,42 @@ func (c *searchEngineClient) CreatePostIndex(ctx context.Context, in *CreatePost
 	return out, nil
 }
 
+func (c *searchEngineClient) UpdatePostIndex(ctx context.Context, in *UpdatePostIndexRequest, opts ...grpc.CallOption) (*UpdatePostIndexResponse, error) {
+	out := new(UpdatePostIndexResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_UpdatePostIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) DeletePostIndex(ctx context.Context, in *DeletePostIndexRequest, opts ...grpc.CallOption) (*DeletePostIndexResponse, error) {
+	out := new(DeletePostIndexResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_DeletePostIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) BulkCreatePostIndex(ctx context.Context, in *BulkCreatePostIndexRequest, opts ...grpc.CallOption) (*BulkCreatePostIndexResponse, error) {
+	out := new(BulkCreatePostIndexResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_BulkCreatePostIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) GetPostIndex(ctx context.Context, in *GetPostIndexRequest, opts ...grpc.CallOption) (*GetPostIndexResponse, error) {
+	out := new(GetPostIndexResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_GetPostIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *searchEngineClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
 	out := new(SearchResponse)
 	err := c.cc.Invoke(ctx, SearchEngine_Search_FullMethodName, in, out, opts...)
@@ -45,9 +105,93 @@ func (c *searchEngineClient) Search(ctx context.Context, in *SearchRequest, opts
 	return out, nil
 }
 
+func (c *searchEngineClient) CreateUserIndex(ctx context.Context, in *UserIndexRequest, opts ...grpc.CallOption) (*UserIndexResponse, error) {
+	out := new(UserIndexResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_CreateUserIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) UpdateUserIndex(ctx context.Context, in *UserIndexRequest, opts ...grpc.CallOption) (*UserIndexResponse, error) {
+	out := new(UserIndexResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_UpdateUserIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) DeleteUserIndex(ctx context.Context, in *DeleteUserIndexRequest, opts ...grpc.CallOption) (*DeleteUserIndexResponse, error) {
+	out := new(DeleteUserIndexResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_DeleteUserIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) SearchUser(ctx context.Context, in *SearchUserRequest, opts ...grpc.CallOption) (*SearchUserResponse, error) {
+	out := new(SearchUserResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_SearchUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) CreateCommentIndex(ctx context.Context, in *CommentIndexRequest, opts ...grpc.CallOption) (*CommentIndexResponse, error) {
+	out := new(CommentIndexResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_CreateCommentIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) UpdateCommentIndex(ctx context.Context, in *CommentIndexRequest, opts ...grpc.CallOption) (*CommentIndexResponse, error) {
+	out := new(CommentIndexResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_UpdateCommentIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) DeleteCommentIndex(ctx context.Context, in *DeleteCommentIndexRequest, opts ...grpc.CallOption) (*DeleteCommentIndexResponse, error) {
+	out := new(DeleteCommentIndexResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_DeleteCommentIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) SearchComment(ctx context.Context, in *SearchCommentRequest, opts ...grpc.CallOption) (*SearchCommentResponse, error) {
+	out := new(SearchCommentResponse)
+	err := c.cc.Invoke(ctx, SearchEngine_SearchComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 type SearchEngineServer interface {
 	CreatePostIndex(context.Context, *CreatePostIndexRequest) (*CreatePostIndexResponse, error)
+	UpdatePostIndex(context.Context, *UpdatePostIndexRequest) (*UpdatePostIndexResponse, error)
+	DeletePostIndex(context.Context, *DeletePostIndexRequest) (*DeletePostIndexResponse, error)
+	BulkCreatePostIndex(context.Context, *BulkCreatePostIndexRequest) (*BulkCreatePostIndexResponse, error)
+	GetPostIndex(context.Context, *GetPostIndexRequest) (*GetPostIndexResponse, error)
 	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	CreateUserIndex(context.Context, *UserIndexRequest) (*UserIndexResponse, error)
+	UpdateUserIndex(context.Context, *UserIndexRequest) (*UserIndexResponse, error)
+	DeleteUserIndex(context.Context, *DeleteUserIndexRequest) (*DeleteUserIndexResponse, error)
+	SearchUser(context.Context, *SearchUserRequest) (*SearchUserResponse, error)
+	CreateCommentIndex(context.Context, *CommentIndexRequest) (*CommentIndexResponse, error)
+	UpdateCommentIndex(context.Context, *CommentIndexRequest) (*CommentIndexResponse, error)
+	DeleteCommentIndex(context.Context, *DeleteCommentIndexRequest) (*DeleteCommentIndexResponse, error)
+	SearchComment(context.Context, *SearchCommentRequest) (*SearchCommentResponse, error)
 	mustEmbedUnimplementedSearchEngineServer()
 }
 
@@ -57,9 +201,45 @@ type UnimplementedSearchEngineServer struct {
 func (UnimplementedSearchEngineServer) CreatePostIndex(context.Context, *CreatePostIndexRequest) (*CreatePostIndexResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreatePostIndex not implemented")
 }
+func (UnimplementedSearchEngineServer) UpdatePostIndex(context.Context, *UpdatePostIndexRequest) (*UpdatePostIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePostIndex not implemented")
+}
+func (UnimplementedSearchEngineServer) DeletePostIndex(context.Context, *DeletePostIndexRequest) (*DeletePostIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePostIndex not implemented")
+}
+func (UnimplementedSearchEngineServer) BulkCreatePostIndex(context.Context, *BulkCreatePostIndexRequest) (*BulkCreatePostIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCreatePostIndex not implemented")
+}
+func (UnimplementedSearchEngineServer) GetPostIndex(context.Context, *GetPostIndexRequest) (*GetPostIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPostIndex not implemented")
+}
 func (UnimplementedSearchEngineServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedSearchEngineServer) CreateUserIndex(context.Context, *UserIndexRequest) (*UserIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUserIndex not implemented")
+}
+func (UnimplementedSearchEngineServer) UpdateUserIndex(context.Context, *UserIndexRequest) (*UserIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserIndex not implemented")
+}
+func (UnimplementedSearchEngineServer) DeleteUserIndex(context.Context, *DeleteUserIndexRequest) (*DeleteUserIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserIndex not implemented")
+}
+func (UnimplementedSearchEngineServer) SearchUser(context.Context, *SearchUserRequest) (*SearchUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchUser not implemented")
+}
+func (UnimplementedSearchEngineServer) CreateCommentIndex(context.Context, *CommentIndexRequest) (*CommentIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCommentIndex not implemented")
+}
+func (UnimplementedSearchEngineServer) UpdateCommentIndex(context.Context, *CommentIndexRequest) (*CommentIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCommentIndex not implemented")
+}
+func (UnimplementedSearchEngineServer) DeleteCommentIndex(context.Context, *DeleteCommentIndexRequest) (*DeleteCommentIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCommentIndex not implemented")
+}
+func (UnimplementedSearchEngineServer) SearchComment(context.Context, *SearchCommentRequest) (*SearchCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchComment not implemented")
+}
 func (UnimplementedSearchEngineServer) mustEmbedUnimplementedSearchEngineServer() {}
 
 type UnsafeSearchEngineServer interface {
@@ -88,6 +268,78 @@ func _SearchEngine_CreatePostIndex_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SearchEngine_UpdatePostIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePostIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).UpdatePostIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_UpdatePostIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).UpdatePostIndex(ctx, req.(*UpdatePostIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_DeletePostIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePostIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).DeletePostIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_DeletePostIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).DeletePostIndex(ctx, req.(*DeletePostIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_BulkCreatePostIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCreatePostIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).BulkCreatePostIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_BulkCreatePostIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).BulkCreatePostIndex(ctx, req.(*BulkCreatePostIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_GetPostIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).GetPostIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_GetPostIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).GetPostIndex(ctx, req.(*GetPostIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SearchEngine_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchRequest)
 	if err := dec(in); err != nil {
@@ -106,6 +358,150 @@ func _SearchEngine_Search_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SearchEngine_CreateUserIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).CreateUserIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_CreateUserIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).CreateUserIndex(ctx, req.(*UserIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_UpdateUserIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).UpdateUserIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_UpdateUserIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).UpdateUserIndex(ctx, req.(*UserIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_DeleteUserIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).DeleteUserIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_DeleteUserIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).DeleteUserIndex(ctx, req.(*DeleteUserIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_SearchUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).SearchUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_SearchUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).SearchUser(ctx, req.(*SearchUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_CreateCommentIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommentIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).CreateCommentIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_CreateCommentIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).CreateCommentIndex(ctx, req.(*CommentIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_UpdateCommentIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommentIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).UpdateCommentIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_UpdateCommentIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).UpdateCommentIndex(ctx, req.(*CommentIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_DeleteCommentIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).DeleteCommentIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_DeleteCommentIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).DeleteCommentIndex(ctx, req.(*DeleteCommentIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_SearchComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).SearchComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_SearchComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).SearchComment(ctx, req.(*SearchCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var SearchEngine_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "SearchEngine",
 	HandlerType: (*SearchEngineServer)(nil),
@@ -114,10 +510,58 @@ var SearchEngine_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreatePostIndex",
 			Handler:    _SearchEngine_CreatePostIndex_Handler,
 		},
+		{
+			MethodName: "UpdatePostIndex",
+			Handler:    _SearchEngine_UpdatePostIndex_Handler,
+		},
+		{
+			MethodName: "DeletePostIndex",
+			Handler:    _SearchEngine_DeletePostIndex_Handler,
+		},
+		{
+			MethodName: "BulkCreatePostIndex",
+			Handler:    _SearchEngine_BulkCreatePostIndex_Handler,
+		},
+		{
+			MethodName: "GetPostIndex",
+			Handler:    _SearchEngine_GetPostIndex_Handler,
+		},
 		{
 			MethodName: "Search",
 			Handler:    _SearchEngine_Search_Handler,
 		},
+		{
+			MethodName: "CreateUserIndex",
+			Handler:    _SearchEngine_CreateUserIndex_Handler,
+		},
+		{
+			MethodName: "UpdateUserIndex",
+			Handler:    _SearchEngine_UpdateUserIndex_Handler,
+		},
+		{
+			MethodName: "DeleteUserIndex",
+			Handler:    _SearchEngine_DeleteUserIndex_Handler,
+		},
+		{
+			MethodName: "SearchUser",
+			Handler:    _SearchEngine_SearchUser_Handler,
+		},
+		{
+			MethodName: "CreateCommentIndex",
+			Handler:    _SearchEngine_CreateCommentIndex_Handler,
+		},
+		{
+			MethodName: "UpdateCommentIndex",
+			Handler:    _SearchEngine_UpdateCommentIndex_Handler,
+		},
+		{
+			MethodName: "DeleteCommentIndex",
+			Handler:    _SearchEngine_DeleteCommentIndex_Handler,
+		},
+		{
+			MethodName: "SearchComment",
+			Handler:    _SearchEngine_SearchComment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "create_post_index.proto",
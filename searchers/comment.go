@@ -0,0 +1,16 @@
+package searchers
+
+import (
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+)
+
+func NewCommentIndexRequest(comment models.CommentInfo) *search.CommentIndexRequest {
+	return &search.CommentIndexRequest{
+		Id:        int64(comment.ID),
+		PostId:    comment.PostID,
+		Uid:       comment.UID,
+		Content:   comment.Content,
+		CreatedAt: comment.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,241 @@
+package searchers
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+)
+
+type localPost struct {
+	uid       uint64
+	createdAt int64
+	hasImages bool
+	title     string
+	content   string
+}
+
+type LocalSearchEngine struct {
+	mutex        sync.RWMutex
+	posts        map[int64]*localPost
+	postIndex    *textIndex
+	users        map[uint64]*localUser
+	comments     map[int64]*localComment
+	commentIndex *textIndex
+}
+
+func NewLocalSearchEngine(db *gorm.DB) (*LocalSearchEngine, error) {
+
+	engine := &LocalSearchEngine{
+		posts:        make(map[int64]*localPost),
+		postIndex:    newTextIndex(),
+		users:        make(map[uint64]*localUser),
+		comments:     make(map[int64]*localComment),
+		commentIndex: newTextIndex(),
+	}
+
+	var posts []models.PostInfo
+	result := db.Model(&models.PostInfo{}).
+		Select("id", "uid", "created_at", "title", "content", "images").
+		FindInBatches(&posts, consts.SEARCH_INDEX_BATCH_SIZE, func(tx *gorm.DB, batch int) error {
+			for _, post := range posts {
+				engine.indexPost(NewPostIndexRequest(post))
+			}
+			return nil
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var users []models.UserInfo
+	result = db.Model(&models.UserInfo{}).
+		Select("id", "username", "nickname").
+		FindInBatches(&users, consts.SEARCH_INDEX_BATCH_SIZE, func(tx *gorm.DB, batch int) error {
+			for _, user := range users {
+				engine.indexUser(NewUserIndexRequest(user))
+			}
+			return nil
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var comments []models.CommentInfo
+	result = db.Model(&models.CommentInfo{}).
+		Select("id", "post_id", "uid", "created_at", "content").
+		FindInBatches(&comments, consts.SEARCH_INDEX_BATCH_SIZE, func(tx *gorm.DB, batch int) error {
+			for _, comment := range comments {
+				engine.indexComment(NewCommentIndexRequest(comment))
+			}
+			return nil
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return engine, nil
+}
+
+func (engine *LocalSearchEngine) CreatePostIndex(ctx context.Context, in *search.CreatePostIndexRequest, opts ...grpc.CallOption) (*search.CreatePostIndexResponse, error) {
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.indexPost(in)
+
+	return &search.CreatePostIndexResponse{}, nil
+}
+
+func (engine *LocalSearchEngine) UpdatePostIndex(ctx context.Context, in *search.UpdatePostIndexRequest, opts ...grpc.CallOption) (*search.UpdatePostIndexResponse, error) {
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.indexPost(&search.CreatePostIndexRequest{
+		Id:        in.Id,
+		Title:     in.Title,
+		Content:   in.Content,
+		Uid:       in.Uid,
+		CreatedAt: in.CreatedAt,
+		HasImages: in.HasImages,
+	})
+
+	return &search.UpdatePostIndexResponse{}, nil
+}
+
+func (engine *LocalSearchEngine) DeletePostIndex(ctx context.Context, in *search.DeletePostIndexRequest, opts ...grpc.CallOption) (*search.DeletePostIndexResponse, error) {
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	for _, id := range in.Ids {
+		engine.postIndex.remove(id)
+		delete(engine.posts, id)
+	}
+
+	return &search.DeletePostIndexResponse{}, nil
+}
+
+func (engine *LocalSearchEngine) BulkCreatePostIndex(ctx context.Context, in *search.BulkCreatePostIndexRequest, opts ...grpc.CallOption) (*search.BulkCreatePostIndexResponse, error) {
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	for _, post := range in.Posts {
+		engine.indexPost(post)
+	}
+
+	return &search.BulkCreatePostIndexResponse{Indexed: uint64(len(in.Posts))}, nil
+}
+
+func (engine *LocalSearchEngine) GetPostIndex(ctx context.Context, in *search.GetPostIndexRequest, opts ...grpc.CallOption) (*search.GetPostIndexResponse, error) {
+
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	posts := make([]*search.CreatePostIndexRequest, 0, len(in.Ids))
+	for _, id := range in.Ids {
+		post, ok := engine.posts[id]
+		if !ok {
+			continue
+		}
+		posts = append(posts, &search.CreatePostIndexRequest{
+			Id:        id,
+			Title:     post.title,
+			Content:   post.content,
+			Uid:       post.uid,
+			CreatedAt: post.createdAt,
+			HasImages: post.hasImages,
+		})
+	}
+
+	return &search.GetPostIndexResponse{Posts: posts}, nil
+}
+
+func (engine *LocalSearchEngine) Search(ctx context.Context, in *search.SearchRequest, opts ...grpc.CallOption) (*search.SearchResponse, error) {
+
+	terms := tokenize(in.Query)
+	if len(terms) == 0 {
+		return &search.SearchResponse{}, nil
+	}
+
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	scores := engine.postIndex.match(terms, func(id int64) bool {
+		return matchPostFilter(engine.posts[id], in.Filter)
+	})
+	ids, total := rank(scores, in.Offset, in.Limit)
+
+	hits := make([]*search.SearchHit, len(ids))
+	for index, id := range ids {
+		post := engine.posts[id]
+		snippet := highlight(post.content, terms)
+		if snippet == "" {
+			snippet = highlight(post.title, terms)
+		}
+		hits[index] = &search.SearchHit{
+			Id:        id,
+			Score:     scores[id],
+			Highlight: snippet,
+		}
+	}
+
+	return &search.SearchResponse{Ids: ids, Hits: hits, Total: total}, nil
+}
+
+func (engine *LocalSearchEngine) indexPost(in *search.CreatePostIndexRequest) {
+
+	terms := tokenize(in.Title)
+	for term, frequency := range terms {
+		terms[term] = frequency * 2
+	}
+	for term, frequency := range tokenize(in.Content) {
+		terms[term] += frequency
+	}
+
+	engine.postIndex.add(in.Id, terms)
+	engine.posts[in.Id] = &localPost{
+		uid:       in.Uid,
+		createdAt: in.CreatedAt,
+		hasImages: in.HasImages,
+		title:     in.Title,
+		content:   in.Content,
+	}
+}
+
+func matchPostFilter(post *localPost, filter *search.SearchFilter) bool {
+
+	if filter == nil {
+		return true
+	}
+
+	if len(filter.Uids) > 0 {
+		matched := false
+		for _, uid := range filter.Uids {
+			if post.uid == uid {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if filter.CreatedAfter != 0 && post.createdAt < filter.CreatedAfter {
+		return false
+	}
+	if filter.CreatedBefore != 0 && post.createdAt > filter.CreatedBefore {
+		return false
+	}
+	if filter.HasImages != nil && post.hasImages != *filter.HasImages {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,80 @@
+package searchers
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+)
+
+type localComment struct {
+	postID  uint64
+	uid     uint64
+	content string
+}
+
+func (engine *LocalSearchEngine) CreateCommentIndex(ctx context.Context, in *search.CommentIndexRequest, opts ...grpc.CallOption) (*search.CommentIndexResponse, error) {
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.indexComment(in)
+
+	return &search.CommentIndexResponse{}, nil
+}
+
+func (engine *LocalSearchEngine) UpdateCommentIndex(ctx context.Context, in *search.CommentIndexRequest, opts ...grpc.CallOption) (*search.CommentIndexResponse, error) {
+	return engine.CreateCommentIndex(ctx, in, opts...)
+}
+
+func (engine *LocalSearchEngine) DeleteCommentIndex(ctx context.Context, in *search.DeleteCommentIndexRequest, opts ...grpc.CallOption) (*search.DeleteCommentIndexResponse, error) {
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	for _, id := range in.Ids {
+		engine.commentIndex.remove(id)
+		delete(engine.comments, id)
+	}
+
+	return &search.DeleteCommentIndexResponse{}, nil
+}
+
+func (engine *LocalSearchEngine) SearchComment(ctx context.Context, in *search.SearchCommentRequest, opts ...grpc.CallOption) (*search.SearchCommentResponse, error) {
+
+	terms := tokenize(in.Query)
+	if len(terms) == 0 {
+		return &search.SearchCommentResponse{}, nil
+	}
+
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	scores := engine.commentIndex.match(terms, func(id int64) bool {
+		comment := engine.comments[id]
+		return (in.PostId == 0 || comment.postID == in.PostId) && (in.Uid == 0 || comment.uid == in.Uid)
+	})
+	ids, total := rank(scores, in.Offset, in.Limit)
+
+	hits := make([]*search.SearchHit, len(ids))
+	for index, id := range ids {
+		hits[index] = &search.SearchHit{
+			Id:        id,
+			Score:     scores[id],
+			Highlight: highlight(engine.comments[id].content, terms),
+		}
+	}
+
+	return &search.SearchCommentResponse{Hits: hits, Total: total}, nil
+}
+
+func (engine *LocalSearchEngine) indexComment(in *search.CommentIndexRequest) {
+
+	engine.commentIndex.add(in.Id, tokenize(in.Content))
+	engine.comments[in.Id] = &localComment{
+		postID:  in.PostId,
+		uid:     in.Uid,
+		content: in.Content,
+	}
+}
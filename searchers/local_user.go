@@ -0,0 +1,176 @@
+package searchers
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+)
+
+type localUser struct {
+	username string
+	nickname string
+}
+
+func (engine *LocalSearchEngine) CreateUserIndex(ctx context.Context, in *search.UserIndexRequest, opts ...grpc.CallOption) (*search.UserIndexResponse, error) {
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.indexUser(in)
+
+	return &search.UserIndexResponse{}, nil
+}
+
+func (engine *LocalSearchEngine) UpdateUserIndex(ctx context.Context, in *search.UserIndexRequest, opts ...grpc.CallOption) (*search.UserIndexResponse, error) {
+	return engine.CreateUserIndex(ctx, in, opts...)
+}
+
+func (engine *LocalSearchEngine) DeleteUserIndex(ctx context.Context, in *search.DeleteUserIndexRequest, opts ...grpc.CallOption) (*search.DeleteUserIndexResponse, error) {
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	for _, uid := range in.Uids {
+		delete(engine.users, uid)
+	}
+
+	return &search.DeleteUserIndexResponse{}, nil
+}
+
+func (engine *LocalSearchEngine) SearchUser(ctx context.Context, in *search.SearchUserRequest, opts ...grpc.CallOption) (*search.SearchUserResponse, error) {
+
+	query := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(in.Query), "@"))
+	if query == "" {
+		return &search.SearchUserResponse{}, nil
+	}
+
+	limit := in.Limit
+	if limit == 0 {
+		limit = consts.SEARCH_USER_DEFAULT_LENGTH
+	}
+	if limit > consts.SEARCH_USER_MAX_LENGTH {
+		limit = consts.SEARCH_USER_MAX_LENGTH
+	}
+
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	hits := make([]*search.UserHit, 0)
+	for uid, user := range engine.users {
+		score := matchUser(query, user)
+		if score > 0 {
+			hits = append(hits, &search.UserHit{Uid: uid, Score: score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Uid < hits[j].Uid
+	})
+	if uint64(len(hits)) > limit {
+		hits = hits[:limit]
+	}
+
+	return &search.SearchUserResponse{Hits: hits}, nil
+}
+
+func (engine *LocalSearchEngine) indexUser(in *search.UserIndexRequest) {
+	engine.users[in.Uid] = &localUser{
+		username: strings.ToLower(in.Username),
+		nickname: strings.ToLower(in.Nickname),
+	}
+}
+
+func matchUser(query string, user *localUser) float64 {
+
+	queryLength := utf8.RuneCountInString(query)
+	closeness := func(name string) float64 {
+		return 1 / float64(1+utf8.RuneCountInString(name)-queryLength)
+	}
+
+	switch {
+	case user.username == query:
+		return 100
+	case strings.HasPrefix(user.username, query):
+		return 80 + closeness(user.username)
+	case user.nickname == query:
+		return 70
+	case strings.HasPrefix(user.nickname, query):
+		return 60 + closeness(user.nickname)
+	}
+
+	for _, word := range strings.Fields(user.nickname) {
+		if strings.HasPrefix(word, query) {
+			return 50 + closeness(word)
+		}
+	}
+
+	if strings.Contains(user.username, query) || strings.Contains(user.nickname, query) {
+		return 30
+	}
+
+	maxDistance := 0
+	switch {
+	case queryLength >= 6:
+		maxDistance = 2
+	case queryLength >= 3:
+		maxDistance = 1
+	}
+	if maxDistance == 0 {
+		return 0
+	}
+
+	distance := prefixDistance(query, user.username)
+	if nicknameDistance := prefixDistance(query, user.nickname); nicknameDistance < distance {
+		distance = nicknameDistance
+	}
+	if distance > maxDistance {
+		return 0
+	}
+
+	return 20 - 5*float64(distance)
+}
+
+func prefixDistance(query, name string) int {
+
+	source := []rune(query)
+	target := []rune(name)
+
+	beforePrevious := make([]int, len(target)+1)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && source[i-1] == target[j-2] && source[i-2] == target[j-1] {
+				current[j] = min(current[j], beforePrevious[j-2]+1)
+			}
+		}
+		beforePrevious, previous, current = previous, current, beforePrevious
+	}
+
+	distance := previous[0]
+	for _, value := range previous {
+		if value < distance {
+			distance = value
+		}
+	}
+
+	return distance
+}
@@ -0,0 +1,28 @@
+package searchers
+
+import (
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+)
+
+func NewPostIndexRequest(post models.PostInfo) *search.CreatePostIndexRequest {
+	return &search.CreatePostIndexRequest{
+		Id:        int64(post.ID),
+		Title:     post.Title,
+		Content:   post.Content,
+		Uid:       post.UID,
+		CreatedAt: post.CreatedAt.Unix(),
+		HasImages: len(post.Images) > 0,
+	}
+}
+
+func NewPostIndexUpdateRequest(post models.PostInfo) *search.UpdatePostIndexRequest {
+	return &search.UpdatePostIndexRequest{
+		Id:        int64(post.ID),
+		Title:     post.Title,
+		Content:   post.Content,
+		Uid:       post.UID,
+		CreatedAt: post.CreatedAt.Unix(),
+		HasImages: len(post.Images) > 0,
+	}
+}
@@ -0,0 +1,214 @@
+package searchers
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+)
+
+type textIndex struct {
+	documents map[int64]map[string]int
+	postings  map[string]map[int64]int
+}
+
+func newTextIndex() *textIndex {
+	return &textIndex{
+		documents: make(map[int64]map[string]int),
+		postings:  make(map[string]map[int64]int),
+	}
+}
+
+func (index *textIndex) add(id int64, terms map[string]int) {
+
+	index.remove(id)
+	if len(terms) == 0 {
+		return
+	}
+
+	index.documents[id] = terms
+	for term, frequency := range terms {
+		postings, ok := index.postings[term]
+		if !ok {
+			postings = make(map[int64]int)
+			index.postings[term] = postings
+		}
+		postings[id] = frequency
+	}
+}
+
+func (index *textIndex) remove(id int64) {
+
+	terms, ok := index.documents[id]
+	if !ok {
+		return
+	}
+
+	for term := range terms {
+		postings := index.postings[term]
+		delete(postings, id)
+		if len(postings) == 0 {
+			delete(index.postings, term)
+		}
+	}
+	delete(index.documents, id)
+}
+
+func (index *textIndex) match(terms map[string]int, accept func(id int64) bool) map[int64]float64 {
+
+	var scores map[int64]float64
+	for term := range terms {
+		postings := index.postings[term]
+		if len(postings) == 0 {
+			return nil
+		}
+		idf := math.Log(1 + float64(len(index.documents))/float64(len(postings)))
+
+		if scores == nil {
+			scores = make(map[int64]float64, len(postings))
+			for id, frequency := range postings {
+				if accept(id) {
+					scores[id] = float64(frequency) * idf
+				}
+			}
+			continue
+		}
+
+		for id := range scores {
+			frequency, ok := postings[id]
+			if !ok {
+				delete(scores, id)
+				continue
+			}
+			scores[id] += float64(frequency) * idf
+		}
+	}
+
+	return scores
+}
+
+func rank(scores map[int64]float64, offset, limit uint64) ([]int64, uint64) {
+
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] > ids[j]
+	})
+
+	total := uint64(len(ids))
+	if limit == 0 {
+		limit = consts.SEARCH_DEFAULT_LENGTH
+	}
+	if limit > consts.SEARCH_MAX_LENGTH {
+		limit = consts.SEARCH_MAX_LENGTH
+	}
+	if offset >= total {
+		return nil, total
+	}
+	ids = ids[offset:]
+	if uint64(len(ids)) > limit {
+		ids = ids[:limit]
+	}
+
+	return ids, total
+}
+
+func scan(text string, yield func(term string, start, end int)) {
+
+	var (
+		word  strings.Builder
+		start int
+	)
+	flush := func(end int) {
+		if word.Len() > 0 {
+			yield(word.String(), start, end)
+			word.Reset()
+		}
+	}
+
+	for offset, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			flush(offset)
+			yield(string(unicode.ToLower(r)), offset, offset+utf8.RuneLen(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if word.Len() == 0 {
+				start = offset
+			}
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flush(offset)
+		}
+	}
+	flush(len(text))
+}
+
+func tokenize(text string) map[string]int {
+
+	terms := make(map[string]int)
+	scan(text, func(term string, start, end int) {
+		terms[term]++
+	})
+
+	return terms
+}
+
+func highlight(text string, terms map[string]int) string {
+
+	var matches [][2]int
+	scan(text, func(term string, start, end int) {
+		if _, ok := terms[term]; !ok {
+			return
+		}
+		if last := len(matches) - 1; last >= 0 && matches[last][1] == start {
+			matches[last][1] = end
+			return
+		}
+		matches = append(matches, [2]int{start, end})
+	})
+	if len(matches) == 0 {
+		return ""
+	}
+
+	start := matches[0][0]
+	for i := 0; i < consts.SEARCH_HIGHLIGHT_CONTEXT && start > 0; i++ {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+	end := start
+	for i := 0; i < consts.SEARCH_HIGHLIGHT_LENGTH && end < len(text); i++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("...")
+	}
+	cursor := start
+	for _, match := range matches {
+		if match[1] > end {
+			break
+		}
+		sb.WriteString(html.EscapeString(text[cursor:match[0]]))
+		sb.WriteString(consts.SEARCH_HIGHLIGHT_PRE_TAG)
+		sb.WriteString(html.EscapeString(text[match[0]:match[1]]))
+		sb.WriteString(consts.SEARCH_HIGHLIGHT_POST_TAG)
+		cursor = match[1]
+	}
+	sb.WriteString(html.EscapeString(text[cursor:end]))
+	if end < len(text) {
+		sb.WriteString("...")
+	}
+
+	return sb.String()
+}
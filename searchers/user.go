@@ -0,0 +1,17 @@
+package searchers
+
+import (
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+)
+
+func NewUserIndexRequest(user models.UserInfo) *search.UserIndexRequest {
+	request := &search.UserIndexRequest{
+		Uid:      uint64(user.ID),
+		Username: user.UserName,
+	}
+	if user.NickName != nil {
+		request.Nickname = *user.NickName
+	}
+	return request
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/utils/encryptors"
+	"github.com/mehakhanaa/complex-micro-blog/utils/serializers"
+)
+
+type AccountService struct {
+	accountStore *stores.AccountStore
+	userStore    *stores.UserStore
+}
+
+func (factory *Factory) NewAccountService() *AccountService {
+	return &AccountService{
+		accountStore: factory.storeFactory.NewAccountStore(),
+		userStore:    factory.storeFactory.NewUserStore(),
+	}
+}
+
+func (service *AccountService) ExportAccount(uid uint64) ([]byte, error) {
+
+	archive, err := service.accountStore.ExportAccount(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+
+	data, err := json.MarshalIndent(serializers.NewAccountExport(archive, time.Now()), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	file, err := writer.Create("account.json")
+	if err != nil {
+		return nil, err
+	}
+	_, err = file.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if archive.User.Avatar != "vanilla.webp" {
+		err = addFileToArchive(writer, path.Join("media", "avatar", archive.User.Avatar), filepath.Join(consts.AVATAR_IMAGE_PATH, archive.User.Avatar))
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, post := range archive.Posts {
+		for _, image := range post.Images {
+			err = addFileToArchive(writer, path.Join("media", "posts", image), filepath.Join(consts.POST_IMAGE_PATH, image))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func addFileToArchive(writer *zip.Writer, name string, source string) error {
+
+	data, err := os.ReadFile(source)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	file, err := writer.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+
+	return err
+}
+
+func (service *AccountService) ScheduleAccountDeletion(uid uint64, password string) (*models.AccountDeletion, error) {
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUID(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encryptors.CompareHashPassword(userAuthInfo.PasswordHash, password, userAuthInfo.Salt)
+	if err != nil {
+		return nil, errors.New("incorrect password")
+	}
+
+	scheduledAt := time.Now().Add(consts.ACCOUNT_DELETION_GRACE_PERIOD * time.Second)
+
+	return service.accountStore.ScheduleAccountDeletion(uid, scheduledAt)
+}
+
+func (service *AccountService) GetAccountDeletion(uid uint64) (*models.AccountDeletion, error) {
+
+	accountDeletion, err := service.accountStore.GetAccountDeletion(uid)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAccountDeletionNotScheduled
+	}
+
+	return accountDeletion, err
+}
+
+func (service *AccountService) CancelAccountDeletion(uid uint64) error {
+
+	err := service.accountStore.CancelAccountDeletion(uid)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrAccountDeletionNotScheduled
+	}
+
+	return err
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+)
+
+func checkMutateAuthority(userStore *stores.UserStore, operatorUID, ownerUID uint64) error {
+
+	if operatorUID == ownerUID {
+		return nil
+	}
+
+	operator, err := userStore.GetUserByUID(operatorUID)
+	if err != nil {
+		return err
+	}
+	if operator.Authority < consts.AUTHORITY_MODERATOR {
+		return ErrPermissionDenied
+	}
+
+	owner, err := userStore.GetUserByUID(ownerUID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if operator.Authority <= owner.Authority {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
@@ -3,28 +3,46 @@ package services
 import (
 	"errors"
 
+	"gorm.io/gorm"
+
 	"github.com/mehakhanaa/complex-micro-blog/models"
 	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
 )
 
 type CommentService struct {
-	commentStore *stores.CommentStore
+	commentStore       *stores.CommentStore
+	userStore          *stores.UserStore
+	notificationWriter *notificationWriter
+	streamStore        *stores.StreamStore
+	entityWriter       *contentEntityWriter
+	relationFilter     *relationFilter
 }
 
 func (factory *Factory) NewCommentService() *CommentService {
 	return &CommentService{
-		commentStore: factory.storeFactory.NewCommentStore(),
+		commentStore:       factory.storeFactory.NewCommentStore(),
+		userStore:          factory.storeFactory.NewUserStore(),
+		notificationWriter: factory.newNotificationWriter(),
+		streamStore:        factory.storeFactory.NewStreamStore(),
+		entityWriter:       factory.newContentEntityWriter(),
+		relationFilter:     factory.newRelationFilter(),
 	}
 }
 
 func (service *CommentService) CreateComment(uid uint64, postID uint64, content string, postStore *stores.PostStore, userStore *stores.UserStore) (uint64, error) {
 
-	existance, err := postStore.ValidatePostExistence(postID)
+	post, err := postStore.GetPost(postID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, errors.New("post does not exist")
+	}
 	if err != nil {
 		return 0, err
 	}
-	if !existance {
-		return 0, errors.New("post does not exist")
+
+	err = service.relationFilter.CheckInteraction(uid, post.UID)
+	if err != nil {
+		return 0, err
 	}
 
 	user, err := userStore.GetUserByUID(uid)
@@ -36,17 +54,44 @@ func (service *CommentService) CreateComment(uid uint64, postID uint64, content
 	if err != nil {
 		return 0, err
 	}
+
+	err = service.entityWriter.SaveEntities(uid, types.CONTENT_SOURCE_COMMENT, commentID, content)
+	if err != nil {
+		return 0, err
+	}
+
+	err = service.notificationWriter.CreateNotification(
+		post.UID,
+		uid,
+		types.NOTIFICATION_TYPE_POST_COMMENT,
+		types.NOTIFICATION_TARGET_POST,
+		postID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	err = service.publishCommentCount(postID)
+	if err != nil {
+		return 0, err
+	}
+
 	return commentID, nil
 }
 
-func (service *CommentService) UpdateComment(commentID uint64, content string) error {
+func (service *CommentService) UpdateComment(uid, commentID uint64, content string) error {
 
-	exists, err := service.commentStore.ValidateCommentExistence(commentID)
+	comment, err := service.commentStore.GetComment(commentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("comment does not exist")
+	}
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return errors.New("comment does not exist")
+
+	err = checkMutateAuthority(service.userStore, uid, comment.UID)
+	if err != nil {
+		return err
 	}
 
 	err = service.commentStore.UpdateComment(commentID, content)
@@ -54,17 +99,22 @@ func (service *CommentService) UpdateComment(commentID uint64, content string) e
 		return err
 	}
 
-	return nil
+	return service.entityWriter.SaveEntities(comment.UID, types.CONTENT_SOURCE_COMMENT, commentID, content)
 }
 
-func (service *CommentService) DeleteComment(commentID uint64) error {
+func (service *CommentService) DeleteComment(uid, commentID uint64) error {
 
-	exists, err := service.commentStore.ValidateCommentExistence(commentID)
+	comment, err := service.commentStore.GetComment(commentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("comment does not exist")
+	}
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return errors.New("comment does not exist")
+
+	err = checkMutateAuthority(service.userStore, uid, comment.UID)
+	if err != nil {
+		return err
 	}
 
 	err = service.commentStore.DeleteComment(commentID)
@@ -73,11 +123,59 @@ func (service *CommentService) DeleteComment(commentID uint64) error {
 		return err
 	}
 
-	return nil
+	err = service.entityWriter.DeleteEntities(types.CONTENT_SOURCE_COMMENT, commentID)
+	if err != nil {
+		return err
+	}
+
+	return service.publishCommentCount(comment.PostID)
+}
+
+func (service *CommentService) publishCommentCount(postID uint64) error {
+
+	count, err := service.commentStore.GetCommentCount(postID)
+	if err != nil {
+		return err
+	}
+
+	return service.streamStore.PublishPostEvent(postID, types.StreamEvent{
+		Type: types.STREAM_EVENT_COMMENT_COUNT,
+		Data: types.CommentCountStreamData{
+			PostID: postID,
+			Count:  count,
+		},
+	})
 }
 
-func (service *CommentService) GetCommentList(postID uint64) ([]models.CommentInfo, error) {
-	return service.commentStore.GetCommentList(postID)
+func (service *CommentService) GetCommentList(postID uint64, viewerUID uint64) ([]models.CommentInfo, error) {
+
+	err := service.relationFilter.CheckPostVisibility(viewerUID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := service.commentStore.GetCommentList(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	hiddenUIDs, err := service.relationFilter.HiddenUIDs(viewerUID)
+	if err != nil {
+		return nil, err
+	}
+	if len(hiddenUIDs) == 0 {
+		return comments, nil
+	}
+
+	filtered := make([]models.CommentInfo, 0, len(comments))
+	for _, comment := range comments {
+		if _, hidden := hiddenUIDs[comment.UID]; hidden {
+			continue
+		}
+		filtered = append(filtered, comment)
+	}
+
+	return filtered, nil
 }
 
 func (service *CommentService) GetCommentInfo(commentID uint64) (models.CommentInfo, int64, error) {
@@ -93,6 +191,10 @@ func (service *CommentService) GetCommentInfo(commentID uint64) (models.CommentI
 	return service.commentStore.GetCommentInfo(commentID)
 }
 
+func (service *CommentService) GetCommentEntities(commentID uint64) ([]models.ContentEntity, error) {
+	return service.entityWriter.GetEntities(types.CONTENT_SOURCE_COMMENT, commentID)
+}
+
 func (service *CommentService) GetCommentUserStatus(uid, commentID uint64) (bool, bool, error) {
 
 	exists, err := service.commentStore.ValidateCommentExistence(commentID)
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/parsers"
+)
+
+type contentEntityWriter struct {
+	entityStore        *stores.EntityStore
+	userStore          *stores.UserStore
+	notificationWriter *notificationWriter
+}
+
+func (factory *Factory) newContentEntityWriter() *contentEntityWriter {
+	return &contentEntityWriter{
+		entityStore:        factory.storeFactory.NewEntityStore(),
+		userStore:          factory.storeFactory.NewUserStore(),
+		notificationWriter: factory.newNotificationWriter(),
+	}
+}
+
+func (writer *contentEntityWriter) SaveEntities(actorUID uint64, sourceType types.ContentSourceType, sourceID uint64, content string) error {
+
+	previousEntities, err := writer.entityStore.GetEntities(sourceType, sourceID)
+	if err != nil {
+		return err
+	}
+	notified := make(map[uint64]bool)
+	for _, entity := range previousEntities {
+		if entity.MentionUID != nil {
+			notified[*entity.MentionUID] = true
+		}
+	}
+
+	var (
+		entities      []models.ContentEntity
+		resolvedUsers = make(map[string]*models.UserInfo)
+	)
+	for _, parsed := range parsers.ParseContentEntities(content) {
+		entity := models.ContentEntity{
+			Type:   parsed.Type,
+			Text:   parsed.Text,
+			Offset: parsed.Offset,
+			Length: parsed.Length,
+		}
+
+		if parsed.Type == types.CONTENT_ENTITY_MENTION {
+			user, ok := resolvedUsers[parsed.Text]
+			if !ok {
+				user, err = writer.userStore.GetUserByUsername(parsed.Text)
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					user = nil
+				} else if err != nil {
+					return err
+				}
+				resolvedUsers[parsed.Text] = user
+			}
+			if user == nil {
+				continue
+			}
+			mentionUID := uint64(user.ID)
+			entity.MentionUID = &mentionUID
+		}
+
+		entities = append(entities, entity)
+	}
+
+	err = writer.entityStore.ReplaceEntities(sourceType, sourceID, entities)
+	if err != nil {
+		return err
+	}
+
+	for _, entity := range entities {
+		if entity.MentionUID == nil || notified[*entity.MentionUID] {
+			continue
+		}
+		notified[*entity.MentionUID] = true
+
+		err = writer.notificationWriter.CreateNotification(
+			*entity.MentionUID,
+			actorUID,
+			types.NOTIFICATION_TYPE_MENTION,
+			types.NotificationTargetType(sourceType),
+			sourceID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (writer *contentEntityWriter) GetEntities(sourceType types.ContentSourceType, sourceID uint64) ([]models.ContentEntity, error) {
+	return writer.entityStore.GetEntities(sourceType, sourceID)
+}
+
+func (writer *contentEntityWriter) GetPostIDsByMention(uid uint64, from string, length int) ([]int64, error) {
+	return writer.entityStore.GetPostIDsByMention(uid, from, length)
+}
+
+func (writer *contentEntityWriter) DeleteEntities(sourceType types.ContentSourceType, sourceID uint64) error {
+	return writer.entityStore.DeleteEntities(sourceType, sourceID)
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrPermissionDenied = errors.New("permission denied")
+
+	ErrSessionNotFound = errors.New("session does not exist")
+
+	ErrRefreshTokenInvalid = errors.New("refresh token is invalid")
+
+	ErrRefreshTokenReused = errors.New("refresh token has already been used, session revoked")
+
+	ErrVerificationTokenInvalid = errors.New("verification token is invalid or expired")
+
+	ErrEmailAlreadyUsed = errors.New("email is already in use")
+
+	ErrLoginChallengeInvalid = errors.New("login challenge is invalid or expired")
+
+	ErrTwoFactorCodeInvalid = errors.New("two-factor code is invalid")
+
+	ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+	ErrAccountDeletionNotScheduled = errors.New("account deletion is not scheduled")
+
+	ErrPrivateAccount = errors.New("this account is private")
+
+	ErrFollowRequestNotFound = errors.New("follow request does not exist")
+
+	ErrBlocked = errors.New("interaction with this user is blocked")
+
+	ErrNotificationCursorInvalid = errors.New("invalid from cursor")
+
+	ErrSearchRebuildRunning = errors.New("search index rebuild is already running")
+)
+
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (err *LoginLockedError) Error() string {
+	return fmt.Sprintf("too many failed attempts, retry after %d seconds", int64(err.RetryAfter.Seconds()))
+}
@@ -1,11 +1,26 @@
 package services
 
-import "github.com/mehakhanaa/complex-micro-blog/stores"
+import (
+	"github.com/mehakhanaa/complex-micro-blog/mailers"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/utils/encryptors"
+	"github.com/mehakhanaa/complex-micro-blog/utils/keys"
+)
 
 type Factory struct {
 	storeFactory *stores.Factory
+	keySet       *keys.KeySet
+	secretBox    *encryptors.SecretBox
+	mailer       mailers.Mailer
+	baseURL      string
 }
 
-func NewFactory(storeFactory *stores.Factory) *Factory {
-	return &Factory{storeFactory: storeFactory}
+func NewFactory(storeFactory *stores.Factory, keySet *keys.KeySet, secretBox *encryptors.SecretBox, mailer mailers.Mailer, baseURL string) *Factory {
+	return &Factory{
+		storeFactory: storeFactory,
+		keySet:       keySet,
+		secretBox:    secretBox,
+		mailer:       mailer,
+		baseURL:      baseURL,
+	}
 }
@@ -1,26 +1,200 @@
 package services
 
 import (
+	"errors"
+
 	"github.com/mehakhanaa/complex-micro-blog/models"
 	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
 )
 
+type followApprover struct {
+	followStore        *stores.FollowStore
+	timelineStore      *stores.TimelineStore
+	notificationWriter *notificationWriter
+}
+
+func (factory *Factory) newFollowApprover() *followApprover {
+	return &followApprover{
+		followStore:        factory.storeFactory.NewFollowStore(),
+		timelineStore:      factory.storeFactory.NewTimelineStore(),
+		notificationWriter: factory.newNotificationWriter(),
+	}
+}
+
+func (approver *followApprover) Approve(uid, requesterID uint64) error {
+
+	deleted, err := approver.followStore.DeleteFollowRequest(requesterID, uid)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrFollowRequestNotFound
+	}
+
+	err = approver.followStore.FollowUser(requesterID, uid)
+	if err != nil {
+		return err
+	}
+
+	err = approver.notificationWriter.CreateNotification(
+		requesterID,
+		uid,
+		types.NOTIFICATION_TYPE_FOLLOW_ACCEPTED,
+		types.NOTIFICATION_TARGET_USER,
+		uid,
+	)
+	if err != nil {
+		return err
+	}
+
+	return approver.AddFollowedPosts(requesterID, uid)
+}
+
+func (approver *followApprover) ApproveAll(uid uint64) error {
+
+	followRequests, err := approver.followStore.GetFollowRequestList(uid)
+	if err != nil {
+		return err
+	}
+
+	for _, followRequest := range followRequests {
+		err = approver.Approve(uid, followRequest.UserID)
+		if err != nil && !errors.Is(err, ErrFollowRequestNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (approver *followApprover) AddFollowedPosts(uid, followedID uint64) error {
+
+	isCelebrity, err := approver.timelineStore.IsCelebrity(followedID)
+	if err != nil {
+		return err
+	}
+	if isCelebrity {
+		return nil
+	}
+
+	return approver.timelineStore.AddUserPosts(uid, followedID)
+}
+
 type FollowService struct {
-	followStore *stores.FollowStore
+	followStore        *stores.FollowStore
+	userStore          *stores.UserStore
+	timelineStore      *stores.TimelineStore
+	notificationWriter *notificationWriter
+	relationFilter     *relationFilter
+	followApprover     *followApprover
 }
 
 func (factory *Factory) NewFollowService() *FollowService {
 	return &FollowService{
-		followStore: factory.storeFactory.NewFollowStore(),
+		followStore:        factory.storeFactory.NewFollowStore(),
+		userStore:          factory.storeFactory.NewUserStore(),
+		timelineStore:      factory.storeFactory.NewTimelineStore(),
+		notificationWriter: factory.newNotificationWriter(),
+		relationFilter:     factory.newRelationFilter(),
+		followApprover:     factory.newFollowApprover(),
 	}
 }
 
-func (service *FollowService) FollowUser(uid, followedID uint64) error {
-	return service.followStore.FollowUser(uid, followedID)
+func (service *FollowService) FollowUser(uid, followedID uint64) (bool, error) {
+
+	err := service.relationFilter.CheckInteraction(uid, followedID)
+	if err != nil {
+		return false, err
+	}
+
+	followedUser, err := service.userStore.GetUserByUID(followedID)
+	if err != nil {
+		return false, err
+	}
+
+	if followedUser.IsPrivate {
+		isFollowing, err := service.followStore.IsFollowing(uid, followedID)
+		if err != nil {
+			return false, err
+		}
+		if !isFollowing {
+			err = service.followStore.CreateFollowRequest(uid, followedID)
+			if err != nil {
+				return false, err
+			}
+			err = service.notificationWriter.CreateNotification(
+				followedID,
+				uid,
+				types.NOTIFICATION_TYPE_FOLLOW_REQUEST,
+				types.NOTIFICATION_TARGET_USER,
+				followedID,
+			)
+			if err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+	}
+
+	_, err = service.followStore.DeleteFollowRequest(uid, followedID)
+	if err != nil {
+		return false, err
+	}
+
+	err = service.followStore.FollowUser(uid, followedID)
+	if err != nil {
+		return false, err
+	}
+
+	err = service.notificationWriter.CreateNotification(
+		followedID,
+		uid,
+		types.NOTIFICATION_TYPE_FOLLOW,
+		types.NOTIFICATION_TARGET_USER,
+		followedID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return false, service.followApprover.AddFollowedPosts(uid, followedID)
 }
 
 func (service *FollowService) CancelFollowUser(uid, followedID uint64) error {
-	return service.followStore.CancelFollowUser(uid, followedID)
+
+	_, err := service.followStore.DeleteFollowRequest(uid, followedID)
+	if err != nil {
+		return err
+	}
+
+	err = service.followStore.CancelFollowUser(uid, followedID)
+	if err != nil {
+		return err
+	}
+
+	return service.timelineStore.RemoveUserPosts(uid, followedID)
+}
+
+func (service *FollowService) GetFollowRequestList(uid uint64) ([]models.FollowRequestInfo, error) {
+	return service.followStore.GetFollowRequestList(uid)
+}
+
+func (service *FollowService) ApproveFollowRequest(uid, requesterID uint64) error {
+	return service.followApprover.Approve(uid, requesterID)
+}
+
+func (service *FollowService) RejectFollowRequest(uid, requesterID uint64) error {
+
+	deleted, err := service.followStore.DeleteFollowRequest(requesterID, uid)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrFollowRequestNotFound
+	}
+
+	return nil
 }
 
 func (service *FollowService) GetFollowList(userID uint64) ([]models.FollowInfo, error) {
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"github.com/mehakhanaa/complex-micro-blog/utils/keys"
+)
+
+type KeyService struct {
+	keySet *keys.KeySet
+}
+
+func (factory *Factory) NewKeyService() *KeyService {
+	return &KeyService{
+		keySet: factory.keySet,
+	}
+}
+
+func (service *KeyService) GetJWKS() keys.JSONWebKeySet {
+	return service.keySet.JWKS()
+}
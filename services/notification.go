@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type notificationWriter struct {
+	notificationStore *stores.NotificationStore
+	relationStore     *stores.RelationStore
+}
+
+func (factory *Factory) newNotificationWriter() *notificationWriter {
+	return &notificationWriter{
+		notificationStore: factory.storeFactory.NewNotificationStore(),
+		relationStore:     factory.storeFactory.NewRelationStore(),
+	}
+}
+
+func (writer *notificationWriter) CreateNotification(uid, actorID uint64, notificationType types.NotificationType, targetType types.NotificationTargetType, targetID uint64) error {
+
+	if actorID != 0 && actorID != uid {
+		blocked, err := writer.relationStore.IsBlockedBetween(uid, actorID)
+		if err != nil {
+			return err
+		}
+		muted, err := writer.relationStore.IsMuted(uid, actorID)
+		if err != nil {
+			return err
+		}
+		if blocked || muted {
+			return nil
+		}
+	}
+
+	return writer.notificationStore.CreateNotification(uid, actorID, notificationType, targetType, targetID)
+}
+
+type NotificationService struct {
+	notificationStore *stores.NotificationStore
+}
+
+func (factory *Factory) NewNotificationService() *NotificationService {
+	return &NotificationService{
+		notificationStore: factory.storeFactory.NewNotificationStore(),
+	}
+}
+
+func (service *NotificationService) GetNotificationList(uid uint64, length, from string) ([]models.NotificationInfo, error) {
+
+	var (
+		queryLength = consts.NOTIFICATION_LIST_MAX_LENGTH
+		fromMilli   int64
+		fromID      primitive.ObjectID
+		err         error
+	)
+	if length != "" {
+		queryLength, err = strconv.Atoi(length)
+		if err != nil {
+			return nil, err
+		}
+		if queryLength > consts.NOTIFICATION_LIST_MAX_LENGTH {
+			queryLength = consts.NOTIFICATION_LIST_MAX_LENGTH
+		}
+	}
+	if from != "" {
+		fromMilli, fromID, err = parseNotificationCursor(from)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return service.notificationStore.GetNotificationList(uid, fromMilli, fromID, queryLength)
+}
+
+func parseNotificationCursor(cursor string) (int64, primitive.ObjectID, error) {
+
+	milli, id, compound := strings.Cut(cursor, "_")
+
+	fromMilli, err := strconv.ParseInt(milli, 10, 64)
+	if err != nil || fromMilli <= 0 {
+		return 0, primitive.NilObjectID, ErrNotificationCursorInvalid
+	}
+	if !compound {
+		return fromMilli, primitive.NilObjectID, nil
+	}
+
+	fromID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return 0, primitive.NilObjectID, ErrNotificationCursorInvalid
+	}
+
+	return fromMilli, fromID, nil
+}
+
+func (service *NotificationService) GetUnreadCount(uid uint64) (int64, error) {
+	return service.notificationStore.GetUnreadCount(uid)
+}
+
+func (service *NotificationService) MarkNotificationRead(uid uint64, notificationID string) error {
+
+	objectID, err := primitive.ObjectIDFromHex(notificationID)
+	if err != nil {
+		return errors.New("notification id is invalid")
+	}
+
+	return service.notificationStore.MarkNotificationRead(uid, objectID)
+}
+
+func (service *NotificationService) MarkAllNotificationsRead(uid uint64) error {
+	return service.notificationStore.MarkAllNotificationsRead(uid)
+}
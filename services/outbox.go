@@ -0,0 +1,27 @@
+package services
+
+import (
+	"time"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+)
+
+type OutboxService struct {
+	outboxStore *stores.OutboxStore
+}
+
+func (factory *Factory) NewOutboxService() *OutboxService {
+	return &OutboxService{
+		outboxStore: factory.storeFactory.NewOutboxStore(),
+	}
+}
+
+func (service *OutboxService) GetOutboxLag() (int64, int64, int64, *time.Time, error) {
+	return service.outboxStore.GetOutboxLag()
+}
+
+func (service *OutboxService) GetDeadOutboxEvents() ([]models.OutboxEvent, error) {
+	return service.outboxStore.GetDeadOutboxEvents(consts.OUTBOX_DEAD_LIST_LENGTH)
+}
@@ -1,15 +1,15 @@
 package services
 
 import (
-	"context"
 	"errors"
 	"mime/multipart"
 	"strconv"
 
 	"github.com/lib/pq"
+	"gorm.io/gorm"
+
 	"github.com/mehakhanaa/complex-micro-blog/consts"
 	"github.com/mehakhanaa/complex-micro-blog/models"
-	search "github.com/mehakhanaa/complex-micro-blog/proto"
 	"github.com/mehakhanaa/complex-micro-blog/stores"
 	"github.com/mehakhanaa/complex-micro-blog/types"
 	"github.com/mehakhanaa/complex-micro-blog/utils/converters"
@@ -17,18 +17,49 @@ import (
 )
 
 type PostService struct {
-	postStore           *stores.PostStore
-	searchServiceClient search.SearchEngineClient
+	postStore          *stores.PostStore
+	userStore          *stores.UserStore
+	followStore        *stores.FollowStore
+	timelineStore      *stores.TimelineStore
+	notificationWriter *notificationWriter
+	entityWriter       *contentEntityWriter
+	relationFilter     *relationFilter
 }
 
-func (factory *Factory) NewPostService(searchServiceClient search.SearchEngineClient) *PostService {
+func (factory *Factory) NewPostService() *PostService {
 	return &PostService{
-		postStore:           factory.storeFactory.NewPostStore(),
-		searchServiceClient: searchServiceClient,
+		postStore:          factory.storeFactory.NewPostStore(),
+		userStore:          factory.storeFactory.NewUserStore(),
+		followStore:        factory.storeFactory.NewFollowStore(),
+		timelineStore:      factory.storeFactory.NewTimelineStore(),
+		notificationWriter: factory.newNotificationWriter(),
+		entityWriter:       factory.newContentEntityWriter(),
+		relationFilter:     factory.newRelationFilter(),
+	}
+}
+
+func (service *PostService) GetPostList(reqType, uid, length, from string, viewerUID uint64, userStore *stores.UserStore) ([]int64, error) {
+
+	postIDs, err := service.getPostList(reqType, uid, length, from, viewerUID, userStore)
+	if err != nil {
+		return nil, err
+	}
+	if reqType == "all" {
+		return postIDs, nil
+	}
+
+	switch reqType {
+	case "mentioned", "liked", "favourited", "reposted":
+		postIDs, err = service.relationFilter.FilterPrivatePostIDs(viewerUID, postIDs)
+		if err != nil {
+			return nil, err
+		}
 	}
+
+	return service.relationFilter.FilterPostIDs(viewerUID, postIDs, reqType == "following")
 }
 
-func (service *PostService) GetPostList(reqType, uid, length, from string, userStore *stores.UserStore) ([]int64, error) {
+func (service *PostService) getPostList(reqType, uid, length, from string, viewerUID uint64, userStore *stores.UserStore) ([]int64, error) {
 	var (
 		postInfos  []models.PostInfo
 		userRecord pq.Int64Array
@@ -54,15 +85,47 @@ func (service *PostService) GetPostList(reqType, uid, length, from string, userS
 		}
 	}
 
+	switch reqType {
+	case "user", "mentioned", "liked", "favourited", "reposted":
+		if viewerUID != 0 {
+			err = service.relationFilter.CheckInteraction(viewerUID, uint64(uidInt64))
+			if err != nil {
+				return nil, err
+			}
+		}
+		visible, err := service.relationFilter.CanViewUserPosts(viewerUID, uint64(uidInt64))
+		if err != nil {
+			return nil, err
+		}
+		if !visible {
+			return nil, ErrPrivateAccount
+		}
+	}
+
 	switch reqType {
 	case "all":
-		postInfos, err = service.postStore.GetPostList(from, queryLenth)
+		var followedIDs, excludedUIDs []uint64
+		followedIDs, err = service.getFollowedIDs(viewerUID)
+		if err != nil {
+			return nil, err
+		}
+		excludedUIDs, err = service.relationFilter.ExcludedUIDs(viewerUID)
+		if err != nil {
+			return nil, err
+		}
+		postInfos, err = service.postStore.GetPostList(from, queryLenth, viewerUID, followedIDs, excludedUIDs)
 	case "user":
 		postInfos, err = service.postStore.GetPostListByUID(uid)
+	case "following":
+		return service.getFollowingPostList(uint64(uidInt64), from, queryLenth)
+	case "mentioned":
+		return service.entityWriter.GetPostIDsByMention(uint64(uidInt64), from, queryLenth)
 	case "liked":
 		userRecord, err = userStore.GetUserLikedRecord(uidInt64)
 	case "favourited":
 		userRecord, err = userStore.GetUserFavoriteRecord(uidInt64)
+	case "reposted":
+		userRecord, err = userStore.GetUserRepostRecord(uidInt64)
 	}
 	if err != nil {
 		return nil, err
@@ -86,11 +149,117 @@ func (service *PostService) GetPostList(reqType, uid, length, from string, userS
 	return postIDs, nil
 }
 
-func (service *PostService) GetPostInfo(postID uint64) (models.PostInfo, int64, int64, error) {
-	return service.postStore.GetPostInfo(postID)
+func (service *PostService) getFollowingPostList(uid uint64, from string, length int) ([]int64, error) {
+
+	followedIDs, err := service.getFollowedIDs(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.timelineStore.GetTimeline(uid, followedIDs, from, length)
+}
+
+func (service *PostService) getFollowedIDs(uid uint64) ([]uint64, error) {
+
+	if uid == 0 {
+		return nil, nil
+	}
+
+	follows, err := service.followStore.GetFollowList(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	followedIDs := make([]uint64, len(follows))
+	for index, follow := range follows {
+		followedIDs[index] = follow.FollowedID
+	}
+
+	return followedIDs, nil
+}
+
+func (service *PostService) GetPostInfo(postID uint64, viewerUID uint64) (models.PostInfo, int64, int64, int64, error) {
+
+	post, likeCount, favouriteCount, forwardCount, err := service.postStore.GetPostInfo(postID)
+	if err != nil {
+		return models.PostInfo{}, 0, 0, 0, err
+	}
+
+	if viewerUID != 0 {
+		err = service.relationFilter.CheckInteraction(viewerUID, post.UID)
+		if err != nil {
+			return models.PostInfo{}, 0, 0, 0, err
+		}
+	}
+
+	visible, err := service.relationFilter.CanViewUserPosts(viewerUID, post.UID)
+	if err != nil {
+		return models.PostInfo{}, 0, 0, 0, err
+	}
+	if !visible {
+		return models.PostInfo{}, 0, 0, 0, ErrPrivateAccount
+	}
+
+	return post, likeCount, favouriteCount, forwardCount, nil
+}
+
+func (service *PostService) GetPostEntities(postID uint64) ([]models.ContentEntity, error) {
+	return service.entityWriter.GetEntities(types.CONTENT_SOURCE_POST, postID)
+}
+
+func (service *PostService) GetParentPost(post models.PostInfo, viewerUID uint64) (*models.PostInfo, bool, error) {
+
+	if post.ParentPostID == nil {
+		return nil, false, nil
+	}
+
+	parentPost, err := service.postStore.GetPost(*post.ParentPostID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	visible, err := service.relationFilter.CanViewUserPosts(viewerUID, parentPost.UID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !visible {
+		return nil, true, nil
+	}
+
+	return &parentPost, false, nil
 }
 
 func (service *PostService) CreatePost(uid uint64, ipAddr string, postReqInfo types.PostCreateBody) (models.PostInfo, error) {
+	return service.createPost(uid, ipAddr, nil, postReqInfo)
+}
+
+func (service *PostService) QuotePost(uid uint64, ipAddr string, parentPostID uint64, postReqInfo types.PostCreateBody) (models.PostInfo, error) {
+
+	existence, err := service.postStore.ValidatePostExistence(parentPostID)
+	if err != nil {
+		return models.PostInfo{}, err
+	}
+	if !existence {
+		return models.PostInfo{}, errors.New("post does not exist")
+	}
+
+	err = service.relationFilter.CheckPostVisibility(uid, parentPostID)
+	if err != nil {
+		return models.PostInfo{}, err
+	}
+
+	err = service.relationFilter.CheckPostInteraction(uid, parentPostID)
+	if err != nil {
+		return models.PostInfo{}, err
+	}
+
+	return service.createPost(uid, ipAddr, &parentPostID, postReqInfo)
+}
+
+func (service *PostService) createPost(uid uint64, ipAddr string, parentPostID *uint64, postReqInfo types.PostCreateBody) (models.PostInfo, error) {
 
 	for _, image := range postReqInfo.Images {
 		existence, err := service.postStore.CheckCacheImageAvaliable(image)
@@ -102,16 +271,12 @@ func (service *PostService) CreatePost(uid uint64, ipAddr string, postReqInfo ty
 		}
 	}
 
-	postInfo, err := service.postStore.CreatePost(uid, ipAddr, postReqInfo)
+	postInfo, err := service.postStore.CreatePost(uid, ipAddr, parentPostID, postReqInfo)
 	if err != nil {
 		return models.PostInfo{}, err
 	}
 
-	_, err = service.searchServiceClient.CreatePostIndex(context.TODO(), &search.CreatePostIndexRequest{
-		Id:      int64(postInfo.ID),
-		Title:   postReqInfo.Title,
-		Content: postReqInfo.Content,
-	})
+	err = service.entityWriter.SaveEntities(uid, types.CONTENT_SOURCE_POST, uint64(postInfo.ID), postReqInfo.Content)
 	if err != nil {
 		return models.PostInfo{}, err
 	}
@@ -148,7 +313,31 @@ func (service *PostService) UploadPostImage(postImage *multipart.FileHeader) (st
 
 func (service *PostService) LikePost(uid, postID int64) error {
 
-	return service.postStore.LikePost(uid, postID)
+	post, err := service.postStore.GetPost(uint64(postID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("post does not exist")
+	}
+	if err != nil {
+		return err
+	}
+
+	err = service.relationFilter.CheckPostInteraction(uint64(uid), uint64(postID))
+	if err != nil {
+		return err
+	}
+
+	err = service.postStore.LikePost(uid, postID)
+	if err != nil {
+		return err
+	}
+
+	return service.notificationWriter.CreateNotification(
+		post.UID,
+		uint64(uid),
+		types.NOTIFICATION_TYPE_POST_LIKE,
+		types.NOTIFICATION_TARGET_POST,
+		uint64(postID),
+	)
 }
 
 func (service *PostService) CancelLikePost(uid, postID int64) error {
@@ -158,6 +347,11 @@ func (service *PostService) CancelLikePost(uid, postID int64) error {
 
 func (service *PostService) FavouritePost(uid, postID int64) error {
 
+	err := service.relationFilter.CheckPostInteraction(uint64(uid), uint64(postID))
+	if err != nil {
+		return err
+	}
+
 	return service.postStore.FavouritePost(uid, postID)
 }
 
@@ -166,12 +360,90 @@ func (service *PostService) CancelFavouritePost(uid, postID int64) error {
 	return service.postStore.CancelFavouritePost(uid, postID)
 }
 
-func (service *PostService) GetPostUserStatus(uid, postID int64) (bool, bool, error) {
+func (service *PostService) RepostPost(uid, postID int64) error {
+
+	existence, err := service.postStore.ValidatePostExistence(uint64(postID))
+	if err != nil {
+		return err
+	}
+	if !existence {
+		return errors.New("post does not exist")
+	}
+
+	err = service.relationFilter.CheckPostVisibility(uint64(uid), uint64(postID))
+	if err != nil {
+		return err
+	}
+
+	err = service.relationFilter.CheckPostInteraction(uint64(uid), uint64(postID))
+	if err != nil {
+		return err
+	}
+
+	return service.postStore.RepostPost(uid, postID)
+}
+
+func (service *PostService) CancelRepostPost(uid, postID int64) error {
+
+	return service.postStore.CancelRepostPost(uid, postID)
+}
+
+func (service *PostService) GetPostUserStatus(uid, postID int64) (bool, bool, bool, error) {
 
 	return service.postStore.GetPostUserStatus(uid, postID)
 }
 
-func (service *PostService) DeletePost(postID uint64) error {
+func (service *PostService) DeletePost(uid, postID uint64) error {
+
+	post, err := service.postStore.GetPost(postID)
+	if err != nil {
+		return err
+	}
+
+	err = checkMutateAuthority(service.userStore, uid, post.UID)
+	if err != nil {
+		return err
+	}
+
+	err = service.postStore.DeletePost(postID)
+	if err != nil {
+		return err
+	}
+
+	err = service.entityWriter.DeleteEntities(types.CONTENT_SOURCE_POST, postID)
+	if err != nil {
+		return err
+	}
+
+	followers, err := service.followStore.GetFollowerList(post.UID)
+	if err != nil {
+		return err
+	}
+
+	followerIDs := make([]uint64, len(followers))
+	for index, follower := range followers {
+		followerIDs[index] = follower.UserID
+	}
+
+	return service.timelineStore.RemovePost(postID, followerIDs)
+}
+
+func (service *PostService) UpdatePost(uid, postID uint64, title, content string) error {
+
+	post, err := service.postStore.GetPost(postID)
+	if err != nil {
+		return err
+	}
+
+	err = checkMutateAuthority(service.userStore, uid, post.UID)
+	if err != nil {
+		return err
+	}
+
+	err = service.postStore.UpdatePost(postID, title, content)
+	if err != nil {
+		return err
+	}
 
-	return service.postStore.DeletePost(postID)
+	return service.entityWriter.SaveEntities(post.UID, types.CONTENT_SOURCE_POST, postID, content)
 }
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+)
+
+type relationFilter struct {
+	relationStore *stores.RelationStore
+	postStore     *stores.PostStore
+	commentStore  *stores.CommentStore
+	userStore     *stores.UserStore
+	followStore   *stores.FollowStore
+}
+
+func (factory *Factory) newRelationFilter() *relationFilter {
+	return &relationFilter{
+		relationStore: factory.storeFactory.NewRelationStore(),
+		postStore:     factory.storeFactory.NewPostStore(),
+		commentStore:  factory.storeFactory.NewCommentStore(),
+		userStore:     factory.storeFactory.NewUserStore(),
+		followStore:   factory.storeFactory.NewFollowStore(),
+	}
+}
+
+func (filter *relationFilter) CheckInteraction(uid uint64, targetIDs ...uint64) error {
+
+	for _, targetID := range targetIDs {
+		if targetID == uid {
+			continue
+		}
+		blocked, err := filter.relationStore.IsBlockedBetween(uid, targetID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return ErrBlocked
+		}
+	}
+
+	return nil
+}
+
+func (filter *relationFilter) FilterPostIDs(viewerUID uint64, postIDs []int64, includeMuted bool) ([]int64, error) {
+	return filter.filterOwnedIDs(viewerUID, postIDs, includeMuted, filter.postStore.GetPostUIDs)
+}
+
+func (filter *relationFilter) FilterCommentIDs(viewerUID uint64, commentIDs []int64, includeMuted bool) ([]int64, error) {
+	return filter.filterOwnedIDs(viewerUID, commentIDs, includeMuted, filter.commentStore.GetCommentUIDs)
+}
+
+func (filter *relationFilter) filterOwnedIDs(viewerUID uint64, ids []int64, includeMuted bool, getOwners func([]int64) (map[int64]uint64, error)) ([]int64, error) {
+
+	if viewerUID == 0 || len(ids) == 0 {
+		return ids, nil
+	}
+
+	hiddenUIDs, err := filter.relationStore.GetHiddenUIDs(viewerUID, includeMuted)
+	if err != nil {
+		return nil, err
+	}
+	if len(hiddenUIDs) == 0 {
+		return ids, nil
+	}
+
+	owners, err := getOwners(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, hidden := hiddenUIDs[owners[id]]; hidden {
+			continue
+		}
+		filtered = append(filtered, id)
+	}
+
+	return filtered, nil
+}
+
+func (filter *relationFilter) CheckPostInteraction(uid, postID uint64, targetIDs ...uint64) error {
+
+	post, err := filter.postStore.GetPost(postID)
+	if err == nil {
+		targetIDs = append(targetIDs, post.UID)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return filter.CheckInteraction(uid, targetIDs...)
+}
+
+func (filter *relationFilter) HiddenUIDs(viewerUID uint64) (map[uint64]struct{}, error) {
+	return filter.relationStore.GetHiddenUIDs(viewerUID, false)
+}
+
+func (filter *relationFilter) ExcludedUIDs(viewerUID uint64) ([]uint64, error) {
+
+	hiddenUIDs, err := filter.relationStore.GetHiddenUIDs(viewerUID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	excludedUIDs := make([]uint64, 0, len(hiddenUIDs))
+	for hiddenUID := range hiddenUIDs {
+		excludedUIDs = append(excludedUIDs, hiddenUID)
+	}
+
+	return excludedUIDs, nil
+}
+
+func (filter *relationFilter) CanViewUserPosts(viewerUID, ownerUID uint64) (bool, error) {
+
+	if viewerUID != 0 && viewerUID == ownerUID {
+		return true, nil
+	}
+
+	owner, err := filter.userStore.GetUserByUID(ownerUID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !owner.IsPrivate {
+		return true, nil
+	}
+	if viewerUID == 0 {
+		return false, nil
+	}
+
+	return filter.followStore.IsFollowing(viewerUID, ownerUID)
+}
+
+func (filter *relationFilter) CheckPostVisibility(viewerUID, postID uint64) error {
+
+	post, err := filter.postStore.GetPost(postID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	visible, err := filter.CanViewUserPosts(viewerUID, post.UID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrPrivateAccount
+	}
+
+	return nil
+}
+
+func (filter *relationFilter) FilterPrivatePostIDs(viewerUID uint64, postIDs []int64) ([]int64, error) {
+	return filter.filterPrivateOwnedIDs(viewerUID, postIDs, filter.postStore.GetPostUIDs)
+}
+
+func (filter *relationFilter) FilterPrivateCommentIDs(viewerUID uint64, commentIDs []int64) ([]int64, error) {
+	return filter.filterPrivateOwnedIDs(viewerUID, commentIDs, filter.commentStore.GetCommentPostUIDs)
+}
+
+func (filter *relationFilter) filterPrivateOwnedIDs(viewerUID uint64, ids []int64, getOwners func([]int64) (map[int64]uint64, error)) ([]int64, error) {
+
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	owners, err := getOwners(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	visibleOwners := make(map[uint64]bool)
+	filtered := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		ownerUID := owners[id]
+		visible, checked := visibleOwners[ownerUID]
+		if !checked {
+			visible, err = filter.CanViewUserPosts(viewerUID, ownerUID)
+			if err != nil {
+				return nil, err
+			}
+			visibleOwners[ownerUID] = visible
+		}
+		if visible {
+			filtered = append(filtered, id)
+		}
+	}
+
+	return filtered, nil
+}
+
+type RelationService struct {
+	relationStore *stores.RelationStore
+	userStore     *stores.UserStore
+	followStore   *stores.FollowStore
+	timelineStore *stores.TimelineStore
+}
+
+func (factory *Factory) NewRelationService() *RelationService {
+	return &RelationService{
+		relationStore: factory.storeFactory.NewRelationStore(),
+		userStore:     factory.storeFactory.NewUserStore(),
+		followStore:   factory.storeFactory.NewFollowStore(),
+		timelineStore: factory.storeFactory.NewTimelineStore(),
+	}
+}
+
+func (service *RelationService) validateTarget(uid, targetID uint64) error {
+
+	if uid == targetID {
+		return errors.New("cannot target yourself")
+	}
+
+	_, err := service.userStore.GetUserByUID(targetID)
+	if err != nil {
+		return errors.New("user does not exist")
+	}
+
+	return nil
+}
+
+func (service *RelationService) BlockUser(uid, targetID uint64) error {
+
+	err := service.validateTarget(uid, targetID)
+	if err != nil {
+		return err
+	}
+
+	err = service.relationStore.BlockUser(uid, targetID)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range [][2]uint64{{uid, targetID}, {targetID, uid}} {
+		_, err = service.followStore.DeleteFollowRequest(pair[0], pair[1])
+		if err != nil {
+			return err
+		}
+		err = service.followStore.CancelFollowUser(pair[0], pair[1])
+		if err != nil {
+			return err
+		}
+		err = service.timelineStore.RemoveUserPosts(pair[0], pair[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (service *RelationService) UnblockUser(uid, targetID uint64) error {
+	return service.relationStore.UnblockUser(uid, targetID)
+}
+
+func (service *RelationService) MuteUser(uid, targetID uint64) error {
+
+	err := service.validateTarget(uid, targetID)
+	if err != nil {
+		return err
+	}
+
+	return service.relationStore.MuteUser(uid, targetID)
+}
+
+func (service *RelationService) UnmuteUser(uid, targetID uint64) error {
+	return service.relationStore.UnmuteUser(uid, targetID)
+}
+
+func (service *RelationService) GetBlockList(uid uint64) ([]models.RelationInfo, error) {
+	return service.relationStore.GetBlockList(uid)
+}
+
+func (service *RelationService) GetMuteList(uid uint64) ([]models.RelationInfo, error) {
+	return service.relationStore.GetMuteList(uid)
+}
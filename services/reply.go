@@ -3,29 +3,40 @@ package services
 import (
 	"errors"
 
+	"gorm.io/gorm"
+
 	"github.com/mehakhanaa/complex-micro-blog/models"
 	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
 )
 
 type ReplyService struct {
-	replyStore *stores.ReplyStore
+	replyStore         *stores.ReplyStore
+	userStore          *stores.UserStore
+	notificationWriter *notificationWriter
+	entityWriter       *contentEntityWriter
+	relationFilter     *relationFilter
 }
 
 func (factory *Factory) NewReplyService() *ReplyService {
 	return &ReplyService{
-		replyStore: factory.storeFactory.NewReplyStore(),
+		replyStore:         factory.storeFactory.NewReplyStore(),
+		userStore:          factory.storeFactory.NewUserStore(),
+		notificationWriter: factory.newNotificationWriter(),
+		entityWriter:       factory.newContentEntityWriter(),
+		relationFilter:     factory.newRelationFilter(),
 	}
 }
 
 func (service *ReplyService) CreateReply(uid, commentID, parentReplyID uint64, content string, commentStore *stores.CommentStore, userStore *stores.UserStore) error {
 
-	isExist, err := commentStore.ValidateCommentExistence(commentID)
+	comment, err := commentStore.GetComment(commentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("comment does not exist")
+	}
 	if err != nil {
 		return err
 	}
-	if !isExist {
-		return errors.New("comment does not exist")
-	}
 
 	var parentReplyUIDField *uint64 = nil
 
@@ -44,48 +55,122 @@ func (service *ReplyService) CreateReply(uid, commentID, parentReplyID uint64, c
 		parentReplyUIDField = &parentReplyInfo.UID
 	}
 
+	interactionTargets := []uint64{comment.UID}
+	if parentReplyUIDField != nil {
+		interactionTargets = append(interactionTargets, *parentReplyUIDField)
+	}
+	err = service.relationFilter.CheckPostInteraction(uid, comment.PostID, interactionTargets...)
+	if err != nil {
+		return err
+	}
+
 	var parentReplyIDField *uint64 = nil
 	if parentReplyID != 0 {
 		parentReplyIDField = &parentReplyID
 	}
 
-	err = service.replyStore.CreateReply(uid, commentID, parentReplyIDField, parentReplyUIDField, content)
+	replyID, err := service.replyStore.CreateReply(uid, commentID, parentReplyIDField, parentReplyUIDField, content)
+	if err != nil {
+		return err
+	}
+
+	err = service.entityWriter.SaveEntities(uid, types.CONTENT_SOURCE_REPLY, replyID, content)
 	if err != nil {
 		return err
 	}
+
+	err = service.notificationWriter.CreateNotification(
+		comment.UID,
+		uid,
+		types.NOTIFICATION_TYPE_COMMENT_REPLY,
+		types.NOTIFICATION_TARGET_COMMENT,
+		commentID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if parentReplyUIDField != nil && *parentReplyUIDField != comment.UID {
+		err = service.notificationWriter.CreateNotification(
+			*parentReplyUIDField,
+			uid,
+			types.NOTIFICATION_TYPE_REPLY_REPLY,
+			types.NOTIFICATION_TARGET_REPLY,
+			parentReplyID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (service *ReplyService) DeleteReply(uid, replyID uint64) error {
 
-	err := service.replyStore.DeleteReply(uid, replyID)
+	reply, err := service.replyStore.GetReply(replyID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("reply does not exist")
+	}
+	if err != nil {
+		return err
+	}
+
+	err = checkMutateAuthority(service.userStore, uid, reply.UID)
+	if err != nil {
+		return err
+	}
+
+	err = service.replyStore.DeleteReply(replyID)
 	if err != nil {
 
 		return err
 	}
 
-	return nil
+	return service.entityWriter.DeleteEntities(types.CONTENT_SOURCE_REPLY, replyID)
 }
 
 func (service *ReplyService) UpdateReply(uid, replyID uint64, content string) error {
 
-	err := service.replyStore.UpdateReply(uid, replyID, content)
+	reply, err := service.replyStore.GetReply(replyID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("reply does not exist")
+	}
 	if err != nil {
 		return err
 	}
 
-	return nil
+	err = checkMutateAuthority(service.userStore, uid, reply.UID)
+	if err != nil {
+		return err
+	}
+
+	err = service.replyStore.UpdateReply(replyID, content)
+	if err != nil {
+		return err
+	}
+
+	return service.entityWriter.SaveEntities(reply.UID, types.CONTENT_SOURCE_REPLY, replyID, content)
 }
 
-func (service *ReplyService) GetReplyList(commentID uint64) ([]uint64, error) {
+func (service *ReplyService) GetReplyList(commentID uint64, viewerUID uint64) ([]uint64, error) {
 
 	replyList, err := service.replyStore.GetReplyList(commentID)
 	if err != nil {
 		return nil, err
 	}
-	replyListUint64 := make([]uint64, len(replyList))
-	for index, reply := range replyList {
-		replyListUint64[index] = uint64(reply.ID)
+
+	hiddenUIDs, err := service.relationFilter.HiddenUIDs(viewerUID)
+	if err != nil {
+		return nil, err
+	}
+
+	replyListUint64 := make([]uint64, 0, len(replyList))
+	for _, reply := range replyList {
+		if _, hidden := hiddenUIDs[reply.UID]; hidden {
+			continue
+		}
+		replyListUint64 = append(replyListUint64, uint64(reply.ID))
 	}
 
 	return replyListUint64, nil
@@ -100,3 +185,7 @@ func (service *ReplyService) GetReplyDetail(replyID uint64) (models.ReplyInfo, e
 
 	return reply, nil
 }
+
+func (service *ReplyService) GetReplyEntities(replyID uint64) ([]models.ContentEntity, error) {
+	return service.entityWriter.GetEntities(types.CONTENT_SOURCE_REPLY, replyID)
+}
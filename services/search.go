@@ -2,22 +2,209 @@ package services
 
 import (
 	"context"
+	"strconv"
 
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
 	search "github.com/mehakhanaa/complex-micro-blog/proto"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
 )
 
 type SearchService struct {
 	searchServiceClient search.SearchEngineClient
+	userStore           *stores.UserStore
+	relationFilter      *relationFilter
 }
 
 func (factory *Factory) NewSearchService(searchServiceClient search.SearchEngineClient) *SearchService {
 	return &SearchService{
 		searchServiceClient: searchServiceClient,
+		userStore:           factory.storeFactory.NewUserStore(),
+		relationFilter:      factory.newRelationFilter(),
 	}
 }
 
-func (service *SearchService) SearchPost(queryString string) (*search.SearchResponse, error) {
-	return service.searchServiceClient.Search(context.TODO(), &search.SearchRequest{
+func parseSearchPage(length, offset string, defaultLength, maxLength uint64) (uint64, uint64, error) {
+
+	var (
+		queryLength = defaultLength
+		queryOffset uint64
+		err         error
+	)
+	if length != "" {
+		queryLength, err = strconv.ParseUint(length, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		if queryLength > maxLength {
+			queryLength = maxLength
+		}
+	}
+	if offset != "" {
+		queryOffset, err = strconv.ParseUint(offset, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return queryLength, queryOffset, nil
+}
+
+func filterSearchTotal(total uint64, hits, filteredHits []*search.SearchHit) uint64 {
+
+	dropped := uint64(len(hits) - len(filteredHits))
+	if total < dropped {
+		return uint64(len(filteredHits))
+	}
+
+	return total - dropped
+}
+
+func filterSearchHits(hits []*search.SearchHit, filter func([]int64) ([]int64, error)) ([]*search.SearchHit, error) {
+
+	ids := make([]int64, len(hits))
+	for index, hit := range hits {
+		ids[index] = hit.Id
+	}
+	visibleIDs, err := filter(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(visibleIDs) == len(hits) {
+		return hits, nil
+	}
+
+	visible := make(map[int64]struct{}, len(visibleIDs))
+	for _, id := range visibleIDs {
+		visible[id] = struct{}{}
+	}
+	filtered := make([]*search.SearchHit, 0, len(visibleIDs))
+	for _, hit := range hits {
+		if _, ok := visible[hit.Id]; ok {
+			filtered = append(filtered, hit)
+		}
+	}
+
+	return filtered, nil
+}
+
+func (service *SearchService) SearchPost(queryString, length, offset string, filter *search.SearchFilter, viewerUID uint64) ([]*search.SearchHit, uint64, error) {
+
+	queryLength, queryOffset, err := parseSearchPage(length, offset, consts.SEARCH_DEFAULT_LENGTH, consts.SEARCH_MAX_LENGTH)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result, err := service.searchServiceClient.Search(context.TODO(), &search.SearchRequest{
+		Query:  queryString,
+		Offset: queryOffset,
+		Limit:  queryLength,
+		Filter: filter,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := result.Hits
+	if len(hits) == 0 && len(result.Ids) > 0 {
+		hits = make([]*search.SearchHit, len(result.Ids))
+		for index, id := range result.Ids {
+			hits[index] = &search.SearchHit{Id: id}
+		}
+	}
+
+	filteredHits, err := filterSearchHits(hits, func(ids []int64) ([]int64, error) {
+		ids, err := service.relationFilter.FilterPostIDs(viewerUID, ids, false)
+		if err != nil {
+			return nil, err
+		}
+		return service.relationFilter.FilterPrivatePostIDs(viewerUID, ids)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return filteredHits, filterSearchTotal(result.Total, hits, filteredHits), nil
+}
+
+func (service *SearchService) SearchComment(queryString, length, offset string, postID, uid uint64, viewerUID uint64) ([]*search.SearchHit, uint64, error) {
+
+	queryLength, queryOffset, err := parseSearchPage(length, offset, consts.SEARCH_DEFAULT_LENGTH, consts.SEARCH_MAX_LENGTH)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result, err := service.searchServiceClient.SearchComment(context.TODO(), &search.SearchCommentRequest{
+		Query:  queryString,
+		Offset: queryOffset,
+		Limit:  queryLength,
+		PostId: postID,
+		Uid:    uid,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits, err := filterSearchHits(result.Hits, func(ids []int64) ([]int64, error) {
+		ids, err := service.relationFilter.FilterCommentIDs(viewerUID, ids, false)
+		if err != nil {
+			return nil, err
+		}
+		return service.relationFilter.FilterPrivateCommentIDs(viewerUID, ids)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return hits, filterSearchTotal(result.Total, result.Hits, hits), nil
+}
+
+func (service *SearchService) SearchUser(queryString, length string, viewerUID uint64) ([]models.UserInfo, error) {
+
+	queryLength, _, err := parseSearchPage(length, "", consts.SEARCH_USER_DEFAULT_LENGTH, consts.SEARCH_USER_MAX_LENGTH)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := service.searchServiceClient.SearchUser(context.TODO(), &search.SearchUserRequest{
 		Query: queryString,
+		Limit: queryLength,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	hiddenUIDs, err := service.relationFilter.HiddenUIDs(viewerUID)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := make([]uint64, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		if _, hidden := hiddenUIDs[hit.Uid]; hidden {
+			continue
+		}
+		uids = append(uids, hit.Uid)
+	}
+	if len(uids) == 0 {
+		return nil, nil
+	}
+
+	users, err := service.userStore.GetUsersByUIDs(uids)
+	if err != nil {
+		return nil, err
+	}
+	userMap := make(map[uint64]models.UserInfo, len(users))
+	for _, user := range users {
+		userMap[uint64(user.ID)] = user
+	}
+
+	orderedUsers := make([]models.UserInfo, 0, len(users))
+	for _, uid := range uids {
+		if user, ok := userMap[uid]; ok {
+			orderedUsers = append(orderedUsers, user)
+		}
+	}
+
+	return orderedUsers, nil
 }
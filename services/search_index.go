@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+	"github.com/mehakhanaa/complex-micro-blog/searchers"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type SearchIndexService struct {
+	searchServiceClient search.SearchEngineClient
+	searchIndexStore    *stores.SearchIndexStore
+	postStore           *stores.PostStore
+	outboxStore         *stores.OutboxStore
+}
+
+func (factory *Factory) NewSearchIndexService(searchServiceClient search.SearchEngineClient) *SearchIndexService {
+	return &SearchIndexService{
+		searchServiceClient: searchServiceClient,
+		searchIndexStore:    factory.storeFactory.NewSearchIndexStore(),
+		postStore:           factory.storeFactory.NewPostStore(),
+		outboxStore:         factory.storeFactory.NewOutboxStore(),
+	}
+}
+
+func (service *SearchIndexService) GetRebuildProgress() (*types.SearchRebuildProgress, error) {
+	return service.searchIndexStore.GetRebuildProgress()
+}
+
+func (service *SearchIndexService) StartRebuild(restart bool) (*types.SearchRebuildProgress, error) {
+
+	progress, err := service.searchIndexStore.GetRebuildProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	if progress.Status == types.SEARCH_REBUILD_STATUS_RUNNING {
+		locked, err := service.searchIndexStore.IsRebuildLocked()
+		if err != nil {
+			return nil, err
+		}
+		if locked {
+			return nil, ErrSearchRebuildRunning
+		}
+		if !restart {
+			return progress, nil
+		}
+	}
+
+	if restart || progress.Status != types.SEARCH_REBUILD_STATUS_FAILED {
+		progress = &types.SearchRebuildProgress{StartedAt: time.Now().Unix()}
+	}
+
+	progress.Total, err = service.postStore.GetPostCount()
+	if err != nil {
+		return nil, err
+	}
+	progress.Status = types.SEARCH_REBUILD_STATUS_RUNNING
+	progress.Error = ""
+
+	err = service.searchIndexStore.SaveRebuildProgress(progress)
+	if err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+func (service *SearchIndexService) VerifyIndex(sample int, repair bool) (*types.SearchVerifyResult, error) {
+
+	minID, maxID, err := service.postStore.GetPostIDBounds()
+	if err != nil {
+		return nil, err
+	}
+	if maxID == 0 {
+		return &types.SearchVerifyResult{}, nil
+	}
+
+	startID := minID
+	if span := maxID - minID + 1; span > uint64(sample) {
+		startID += uint64(rand.Int63n(int64(span - uint64(sample) + 1)))
+	}
+	endID := startID + uint64(sample) - 1
+
+	posts, err := service.postStore.GetPostsInIDRange(startID, endID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, sample)
+	for id := startID; id <= endID; id++ {
+		ids = append(ids, int64(id))
+	}
+
+	resp, err := service.searchServiceClient.GetPostIndex(context.Background(), &search.GetPostIndexRequest{Ids: ids})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("search service returned code %d", resp.Code)
+	}
+
+	indexed := make(map[int64]*search.CreatePostIndexRequest, len(resp.Posts))
+	for _, post := range resp.Posts {
+		indexed[post.Id] = post
+	}
+
+	result := &types.SearchVerifyResult{Sampled: len(ids)}
+	for _, post := range posts {
+		expected := searchers.NewPostIndexRequest(post)
+		actual, ok := indexed[expected.Id]
+		if !ok {
+			result.MissingIDs = append(result.MissingIDs, uint64(post.ID))
+			continue
+		}
+		delete(indexed, expected.Id)
+		if !proto.Equal(expected, actual) {
+			result.StaleIDs = append(result.StaleIDs, uint64(post.ID))
+		}
+	}
+	for _, id := range ids {
+		if _, ok := indexed[id]; ok {
+			result.OrphanIDs = append(result.OrphanIDs, uint64(id))
+		}
+	}
+
+	if repair {
+		err = service.outboxStore.CreateOutboxEvents(types.OUTBOX_EVENT_POST_INDEX_CREATE, result.MissingIDs...)
+		if err != nil {
+			return nil, err
+		}
+		err = service.outboxStore.CreateOutboxEvents(types.OUTBOX_EVENT_POST_INDEX_UPDATE, result.StaleIDs...)
+		if err != nil {
+			return nil, err
+		}
+		err = service.outboxStore.CreateOutboxEvents(types.OUTBOX_EVENT_POST_INDEX_DELETE, result.OrphanIDs...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
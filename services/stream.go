@@ -0,0 +1,35 @@
+package services
+
+import (
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type StreamService struct {
+	streamStore *stores.StreamStore
+	userStore   *stores.UserStore
+}
+
+func (factory *Factory) NewStreamService() *StreamService {
+	return &StreamService{
+		streamStore: factory.storeFactory.NewStreamStore(),
+		userStore:   factory.storeFactory.NewUserStore(),
+	}
+}
+
+func (service *StreamService) Subscribe(uid uint64, postIDs []uint64) (*redis.PubSub, error) {
+	return service.streamStore.Subscribe(uid, postIDs)
+}
+
+func (service *StreamService) IsTokenAvaliable(claims *types.BearerTokenClaims) (bool, error) {
+
+	if claims.ExpiresAt != nil && !time.Now().Before(claims.ExpiresAt.Time) {
+		return false, nil
+	}
+
+	return service.userStore.IsUserTokenAvaliable(claims)
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+)
+
+type TagService struct {
+	entityStore    *stores.EntityStore
+	relationFilter *relationFilter
+}
+
+func (factory *Factory) NewTagService() *TagService {
+	return &TagService{
+		entityStore:    factory.storeFactory.NewEntityStore(),
+		relationFilter: factory.newRelationFilter(),
+	}
+}
+
+func (service *TagService) GetTagPostList(tag, length, from string, viewerUID uint64) ([]int64, error) {
+
+	queryLength := 10
+	if length != "" {
+		var err error
+		queryLength, err = strconv.Atoi(length)
+		if err != nil {
+			return nil, err
+		}
+		if queryLength > 10 {
+			queryLength = 10
+		}
+	}
+
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+
+	postIDs, err := service.entityStore.GetPostIDsByHashtag(tag, from, queryLength)
+	if err != nil {
+		return nil, err
+	}
+
+	postIDs, err = service.relationFilter.FilterPostIDs(viewerUID, postIDs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.relationFilter.FilterPrivatePostIDs(viewerUID, postIDs)
+}
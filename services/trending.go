@@ -0,0 +1,128 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/stores"
+)
+
+type TrendingService struct {
+	trendingStore  *stores.TrendingStore
+	relationFilter *relationFilter
+}
+
+func (factory *Factory) NewTrendingService() *TrendingService {
+	return &TrendingService{
+		trendingStore:  factory.storeFactory.NewTrendingStore(),
+		relationFilter: factory.newRelationFilter(),
+	}
+}
+
+func (service *TrendingService) parseTrendingQuery(window, length string) (string, int, error) {
+
+	if window == "" {
+		window = consts.TRENDING_DEFAULT_WINDOW
+	}
+	if _, ok := consts.TRENDING_WINDOWS[window]; !ok {
+		return "", 0, errors.New("invalid window")
+	}
+
+	queryLength := 10
+	if length != "" {
+		var err error
+		queryLength, err = strconv.Atoi(length)
+		if err != nil {
+			return "", 0, err
+		}
+		if queryLength <= 0 {
+			return "", 0, errors.New("invalid length")
+		}
+		if queryLength > consts.TRENDING_LIST_MAX_LENGTH {
+			queryLength = consts.TRENDING_LIST_MAX_LENGTH
+		}
+	}
+
+	return window, queryLength, nil
+}
+
+func (service *TrendingService) GetTrendingPosts(viewerUID uint64, window, length string) (string, []redis.Z, error) {
+
+	window, queryLength, err := service.parseTrendingQuery(window, length)
+	if err != nil {
+		return "", nil, err
+	}
+
+	posts, err := service.trendingStore.GetTrendingPosts(window, consts.TRENDING_LIST_MAX_LENGTH)
+	if err != nil {
+		return "", nil, err
+	}
+
+	posts, err = service.filterTrendingPosts(viewerUID, posts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return window, posts[:min(len(posts), queryLength)], nil
+}
+
+func (service *TrendingService) filterTrendingPosts(viewerUID uint64, posts []redis.Z) ([]redis.Z, error) {
+
+	postIDs := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		member, ok := post.Member.(string)
+		if !ok {
+			continue
+		}
+		postID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	postIDs, err := service.relationFilter.FilterPostIDs(viewerUID, postIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	postIDs, err = service.relationFilter.FilterPrivatePostIDs(viewerUID, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make(map[string]struct{}, len(postIDs))
+	for _, postID := range postIDs {
+		visible[strconv.FormatInt(postID, 10)] = struct{}{}
+	}
+
+	filtered := make([]redis.Z, 0, len(postIDs))
+	for _, post := range posts {
+		member, ok := post.Member.(string)
+		if !ok {
+			continue
+		}
+		if _, ok := visible[member]; ok {
+			filtered = append(filtered, post)
+		}
+	}
+
+	return filtered, nil
+}
+
+func (service *TrendingService) GetTrendingTags(window, length string) (string, []redis.Z, error) {
+
+	window, queryLength, err := service.parseTrendingQuery(window, length)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tags, err := service.trendingStore.GetTrendingTags(window, queryLength)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return window, tags, nil
+}
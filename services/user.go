@@ -7,25 +7,47 @@ import (
 	"strings"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 
 	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/mailers"
 	"github.com/mehakhanaa/complex-micro-blog/models"
 	"github.com/mehakhanaa/complex-micro-blog/stores"
 	"github.com/mehakhanaa/complex-micro-blog/types"
 	"github.com/mehakhanaa/complex-micro-blog/utils/converters"
 	"github.com/mehakhanaa/complex-micro-blog/utils/encryptors"
 	"github.com/mehakhanaa/complex-micro-blog/utils/generators"
+	"github.com/mehakhanaa/complex-micro-blog/utils/keys"
+	"github.com/mehakhanaa/complex-micro-blog/utils/parsers"
 	"github.com/mehakhanaa/complex-micro-blog/utils/validers"
 )
 
 type UserService struct {
-	userStore *stores.UserStore
+	userStore         *stores.UserStore
+	sessionStore      *stores.SessionStore
+	loginAttemptStore *stores.LoginAttemptStore
+	verificationStore *stores.VerificationStore
+	twoFactorStore    *stores.TwoFactorStore
+	followApprover    *followApprover
+	keySet            *keys.KeySet
+	secretBox         *encryptors.SecretBox
+	mailer            mailers.Mailer
+	baseURL           string
 }
 
 func (factory *Factory) NewUserService() *UserService {
 	return &UserService{
-		userStore: factory.storeFactory.NewUserStore(),
+		userStore:         factory.storeFactory.NewUserStore(),
+		sessionStore:      factory.storeFactory.NewSessionStore(),
+		loginAttemptStore: factory.storeFactory.NewLoginAttemptStore(),
+		verificationStore: factory.storeFactory.NewVerificationStore(),
+		twoFactorStore:    factory.storeFactory.NewTwoFactorStore(),
+		followApprover:    factory.newFollowApprover(),
+		keySet:            factory.keySet,
+		secretBox:         factory.secretBox,
+		mailer:            factory.mailer,
+		baseURL:           factory.baseURL,
 	}
 }
 
@@ -47,7 +69,7 @@ func (service *UserService) GetUserInfoByUsername(username string) (*models.User
 	return user, nil
 }
 
-func (service *UserService) RegisterUser(username string, password string) error {
+func (service *UserService) RegisterUser(username string, password string, email string) error {
 
 	if !validers.IsValidUsername(username) {
 		return errors.New("invalid username")
@@ -56,33 +78,69 @@ func (service *UserService) RegisterUser(username string, password string) error
 		return errors.New("invalid password")
 	}
 
+	var emailField *string
+	if email != "" {
+		email = normalizeEmail(email)
+		if !validers.IsValidEmail(email) {
+			return errors.New("invalid email")
+		}
+		_, err := service.userStore.GetUserAuthInfoByEmail(email)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			if err != nil {
+				return err
+			}
+			return ErrEmailAlreadyUsed
+		}
+		emailField = &email
+	}
+
 	_, err := service.userStore.GetUserByUsername(username)
 	if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return errors.New("username already exists")
 	}
 
-	salt, err := generators.GenerateSalt(consts.SALT_LENGTH)
+	hashedPassword, err := encryptors.HashPassword(password)
 	if err != nil {
 		return err
 	}
-	hashedPassword, err := encryptors.HashPassword(password, salt)
+
+	err = service.userStore.RegisterUserByUsername(username, emailField, hashedPassword)
 	if err != nil {
 		return err
 	}
 
-	err = service.userStore.RegisterUserByUsername(username, salt, hashedPassword)
+	if emailField == nil {
+		return nil
+	}
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUsername(username)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return service.sendEmailVerification(userAuthInfo.UID, email)
 }
 
-func (service *UserService) LoginUser(username string, password string, ip string, app string, device string) (string, error) {
+func (service *UserService) LoginUser(username string, password string, ip string, app string, device string) (types.TokenPair, string, error) {
+
+	lockTTL, err := service.loginAttemptStore.GetLockTTL(username, ip)
+	if err != nil {
+		return types.TokenPair{}, "", err
+	}
 
 	userAuthInfo, err := service.userStore.GetUserAuthInfoByUsername(username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if lockTTL > 0 {
+			return types.TokenPair{}, "", &LoginLockedError{RetryAfter: lockTTL}
+		}
+		_, inner_err := service.loginAttemptStore.RecordFailure(username, ip)
+		if inner_err != nil {
+			return types.TokenPair{}, "", errors.Join(err, inner_err)
+		}
+		return types.TokenPair{}, "", err
+	}
 	if err != nil {
-		return "", err
+		return types.TokenPair{}, "", err
 	}
 
 	userLoginLog := &models.UserLoginLog{
@@ -95,44 +153,590 @@ func (service *UserService) LoginUser(username string, password string, ip strin
 		IfChecked:   false,
 	}
 
+	if lockTTL > 0 {
+		userLoginLog.Reason = "account locked"
+		err = service.userStore.CreateUserLoginLog(userLoginLog)
+		if err != nil {
+			return types.TokenPair{}, "", err
+		}
+		return types.TokenPair{}, "", &LoginLockedError{RetryAfter: lockTTL}
+	}
+
 	err = encryptors.CompareHashPassword(userAuthInfo.PasswordHash, password, userAuthInfo.Salt)
 	if err != nil {
 		userLoginLog.Reason = "password error"
 		inner_err := service.userStore.CreateUserLoginLog(userLoginLog)
 		if inner_err != nil {
-			return "", errors.Join(err, inner_err)
+			return types.TokenPair{}, "", errors.Join(err, inner_err)
 		}
-		return "", errors.New("password error")
+		_, inner_err = service.loginAttemptStore.RecordFailure(username, ip)
+		if inner_err != nil {
+			return types.TokenPair{}, "", errors.Join(err, inner_err)
+		}
+		return types.TokenPair{}, "", errors.New("password error")
 	}
 
-	token, claims, err := generators.GenerateToken(userAuthInfo.UID, username)
+	err = service.rehashPassword(userAuthInfo, password)
 	if err != nil {
-		userLoginLog.Reason = "token generation error"
-		inner_err := service.userStore.CreateUserLoginLog(userLoginLog)
-		if inner_err != nil {
-			return "", errors.Join(err, inner_err)
+		return types.TokenPair{}, "", err
+	}
+
+	if userAuthInfo.TOTPEnabled {
+		challengeToken, err := service.createLoginChallenge(userAuthInfo, ip, app, device)
+		if err != nil {
+			return types.TokenPair{}, "", err
 		}
-		return "", err
+
+		userLoginLog.Reason = consts.LOGIN_REASON_TWO_FACTOR_CHALLENGE
+		err = service.userStore.CreateUserLoginLog(userLoginLog)
+		if err != nil {
+			return types.TokenPair{}, "", err
+		}
+
+		return types.TokenPair{}, challengeToken, nil
+	}
+
+	tokenPair, err := service.completeLogin(userLoginLog, username)
+	if err != nil {
+		return types.TokenPair{}, "", err
+	}
+
+	return tokenPair, "", nil
+}
+
+func (service *UserService) rehashPassword(userAuthInfo *models.UserAuthInfo, password string) error {
+
+	if !encryptors.NeedsRehash(userAuthInfo.PasswordHash) {
+		return nil
+	}
+
+	hashedPassword, err := encryptors.HashPassword(password)
+	if err != nil {
+		return err
 	}
 
-	err = service.userStore.CreateUserAvaliableToken(token, claims)
+	err = service.userStore.UpdateUserPasswordByUsername(userAuthInfo.UserName, hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	userAuthInfo.PasswordHash = hashedPassword
+
+	return nil
+}
+
+func (service *UserService) completeLogin(userLoginLog *models.UserLoginLog, username string) (types.TokenPair, error) {
+
+	err := service.loginAttemptStore.ResetFailures(username)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	tokenPair, sessionID, err := service.createSession(userLoginLog.UID, username)
 	if err != nil {
 		userLoginLog.Reason = "token creation error"
 		inner_err := service.userStore.CreateUserLoginLog(userLoginLog)
 		if inner_err != nil {
-			return "", errors.Join(err, inner_err)
+			return types.TokenPair{}, errors.Join(err, inner_err)
 		}
-		return "", err
+		return types.TokenPair{}, err
 	}
 
 	userLoginLog.IsSucceed = true
-	userLoginLog.BearerToken = token
+	userLoginLog.BearerToken = tokenPair.AccessToken
+	userLoginLog.SessionID = sessionID
 	err = service.userStore.CreateUserLoginLog(userLoginLog)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	return tokenPair, nil
+}
+
+func (service *UserService) createLoginChallenge(userAuthInfo *models.UserAuthInfo, ip string, app string, device string) (string, error) {
+
+	challengeToken, err := generators.GenerateSalt(consts.LOGIN_CHALLENGE_TOKEN_LENGTH)
+	if err != nil {
+		return "", err
+	}
+
+	err = service.twoFactorStore.CreateLoginChallenge(encryptors.HashToken(challengeToken), types.LoginChallenge{
+		UID:         userAuthInfo.UID,
+		Username:    userAuthInfo.UserName,
+		IP:          ip,
+		Application: app,
+		Device:      device,
+	})
 	if err != nil {
 		return "", err
 	}
 
-	return token, nil
+	return challengeToken, nil
+}
+
+func (service *UserService) LoginWithTwoFactor(challengeToken string, code string) (types.TokenPair, error) {
+
+	challengeHash := encryptors.HashToken(challengeToken)
+
+	challenge, err := service.twoFactorStore.GetLoginChallenge(challengeHash)
+	if errors.Is(err, redis.Nil) {
+		return types.TokenPair{}, ErrLoginChallengeInvalid
+	}
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	lockTTL, err := service.loginAttemptStore.GetLockTTL(challenge.Username, challenge.IP)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+	if lockTTL > 0 {
+		return types.TokenPair{}, &LoginLockedError{RetryAfter: lockTTL}
+	}
+
+	attempts, err := service.twoFactorStore.IncrLoginChallengeAttempts(challengeHash)
+	if errors.Is(err, redis.Nil) {
+		return types.TokenPair{}, ErrLoginChallengeInvalid
+	}
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+	if attempts > consts.LOGIN_CHALLENGE_MAX_ATTEMPTS {
+		err = service.twoFactorStore.DeleteLoginChallenge(challengeHash)
+		if err != nil {
+			return types.TokenPair{}, err
+		}
+		return types.TokenPair{}, ErrLoginChallengeInvalid
+	}
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUID(challenge.UID)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	userLoginLog := &models.UserLoginLog{
+		UID:         challenge.UID,
+		LoginTime:   time.Now(),
+		LoginIP:     challenge.IP,
+		Application: challenge.Application,
+		Device:      challenge.Device,
+		IsSucceed:   false,
+		IfChecked:   false,
+	}
+
+	isRecoveryCode, ok, err := service.verifySecondFactor(userAuthInfo, code)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+	if !ok {
+		userLoginLog.Reason = "two-factor code error"
+		if isRecoveryCode {
+			userLoginLog.Reason = "recovery code error"
+		}
+		inner_err := service.userStore.CreateUserLoginLog(userLoginLog)
+		if inner_err != nil {
+			return types.TokenPair{}, errors.Join(ErrTwoFactorCodeInvalid, inner_err)
+		}
+		_, inner_err = service.loginAttemptStore.RecordFailure(challenge.Username, challenge.IP)
+		if inner_err != nil {
+			return types.TokenPair{}, errors.Join(ErrTwoFactorCodeInvalid, inner_err)
+		}
+		return types.TokenPair{}, ErrTwoFactorCodeInvalid
+	}
+
+	err = service.twoFactorStore.DeleteLoginChallenge(challengeHash)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	userLoginLog.Reason = "two-factor code passed"
+	if isRecoveryCode {
+		userLoginLog.Reason = "recovery code used"
+	}
+
+	return service.completeLogin(userLoginLog, challenge.Username)
+}
+
+func (service *UserService) verifySecondFactor(userAuthInfo *models.UserAuthInfo, code string) (bool, bool, error) {
+
+	code = strings.ToLower(strings.TrimSpace(code))
+
+	if !userAuthInfo.TOTPEnabled || userAuthInfo.TOTPSecret == nil {
+		return false, false, ErrTwoFactorNotEnabled
+	}
+
+	if !strings.Contains(code, "-") {
+		secret, err := service.secretBox.Open(*userAuthInfo.TOTPSecret)
+		if err != nil {
+			return false, false, err
+		}
+		counter, ok := validers.ValidateTOTPCode(secret, code, time.Now())
+		if !ok {
+			return false, false, nil
+		}
+		fresh, err := service.twoFactorStore.MarkCounterUsed(userAuthInfo.UID, counter)
+		if err != nil {
+			return false, false, err
+		}
+		return false, fresh, nil
+	}
+
+	for _, recoveryCodeHash := range userAuthInfo.RecoveryCodes {
+		if !service.secretBox.CompareRecoveryCode(recoveryCodeHash, code) {
+			continue
+		}
+
+		consumed, err := service.twoFactorStore.ConsumeRecoveryCode(userAuthInfo.UID, recoveryCodeHash)
+		if err != nil {
+			return true, false, err
+		}
+		return true, consumed, nil
+	}
+
+	return true, false, nil
+}
+
+func (service *UserService) EnrollTwoFactor(uid uint64) (string, string, error) {
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUID(uid)
+	if err != nil {
+		return "", "", err
+	}
+	if userAuthInfo.TOTPEnabled {
+		return "", "", ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := generators.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	sealedSecret, err := service.secretBox.Seal(secret)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = service.twoFactorStore.SetPendingSecret(uid, sealedSecret)
+	if err != nil {
+		return "", "", err
+	}
+
+	return secret, generators.GenerateTOTPURI(userAuthInfo.UserName, secret), nil
+}
+
+func (service *UserService) ConfirmTwoFactor(uid uint64, code string) ([]string, error) {
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUID(uid)
+	if err != nil {
+		return nil, err
+	}
+	if userAuthInfo.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	sealedSecret, err := service.twoFactorStore.GetPendingSecret(uid)
+	if errors.Is(err, redis.Nil) {
+		return nil, errors.New("two-factor enrollment has expired")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := service.secretBox.Open(sealedSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	counter, ok := validers.ValidateTOTPCode(secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return nil, ErrTwoFactorCodeInvalid
+	}
+	_, err = service.twoFactorStore.MarkCounterUsed(uid, counter)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := service.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.twoFactorStore.EnableTwoFactor(uid, sealedSecret, recoveryCodeHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.twoFactorStore.DeletePendingSecret(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (service *UserService) DisableTwoFactor(uid uint64, password string, code string) error {
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUID(uid)
+	if err != nil {
+		return err
+	}
+
+	err = encryptors.CompareHashPassword(userAuthInfo.PasswordHash, password, userAuthInfo.Salt)
+	if err != nil {
+		return errors.New("incorrect password")
+	}
+
+	_, ok, err := service.verifySecondFactor(userAuthInfo, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTwoFactorCodeInvalid
+	}
+
+	return service.twoFactorStore.DisableTwoFactor(uid)
+}
+
+func (service *UserService) RegenerateRecoveryCodes(uid uint64, code string) ([]string, error) {
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUID(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	isRecoveryCode, ok, err := service.verifySecondFactor(userAuthInfo, code)
+	if err != nil {
+		return nil, err
+	}
+	if isRecoveryCode || !ok {
+		return nil, ErrTwoFactorCodeInvalid
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := service.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.twoFactorStore.UpdateRecoveryCodes(uid, recoveryCodeHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (service *UserService) generateRecoveryCodes() ([]string, []string, error) {
+
+	recoveryCodes, err := generators.GenerateRecoveryCodes(consts.RECOVERY_CODE_COUNT)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recoveryCodeHashes := make([]string, len(recoveryCodes))
+	for index, recoveryCode := range recoveryCodes {
+		recoveryCodeHashes[index] = service.secretBox.HashRecoveryCode(recoveryCode)
+	}
+
+	return recoveryCodes, recoveryCodeHashes, nil
+}
+
+func (service *UserService) createSession(uid uint64, username string) (types.TokenPair, string, error) {
+
+	sessions, err := service.sessionStore.GetSessionList(uid)
+	if err != nil {
+		return types.TokenPair{}, "", err
+	}
+	for index := len(sessions) - 1; index >= consts.MAX_TOKENS_PER_USER-1; index-- {
+		err = service.revokeSession(uid, sessions[index])
+		if err != nil {
+			return types.TokenPair{}, "", err
+		}
+	}
+
+	sessionID := generators.GenerateSessionID()
+
+	token, claims, err := generators.GenerateToken(service.keySet, uid, username, sessionID)
+	if err != nil {
+		return types.TokenPair{}, "", err
+	}
+
+	refreshToken, refreshSecret, err := generators.GenerateRefreshToken(uid, sessionID)
+	if err != nil {
+		return types.TokenPair{}, "", err
+	}
+
+	err = service.userStore.CreateUserAvaliableToken(claims)
+	if err != nil {
+		return types.TokenPair{}, "", err
+	}
+
+	err = service.sessionStore.CreateSession(uid, sessionID, encryptors.HashToken(refreshSecret), claims.ID)
+	if err != nil {
+		return types.TokenPair{}, "", err
+	}
+
+	return types.TokenPair{
+		AccessToken:  token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    consts.TOKEN_EXPIRE_DURATION,
+	}, sessionID, nil
+}
+
+func (service *UserService) RefreshToken(refreshToken string) (types.TokenPair, error) {
+
+	uid, sessionID, refreshSecret, err := parsers.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return types.TokenPair{}, ErrRefreshTokenInvalid
+	}
+
+	session, err := service.sessionStore.GetSession(uid, sessionID)
+	if errors.Is(err, redis.Nil) {
+		return types.TokenPair{}, ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	if !encryptors.CompareHashToken(session.RefreshHash, refreshSecret) {
+		if session.PreviousHash != "" && encryptors.CompareHashToken(session.PreviousHash, refreshSecret) {
+			err = service.revokeSession(uid, *session)
+			if err != nil {
+				return types.TokenPair{}, err
+			}
+			return types.TokenPair{}, ErrRefreshTokenReused
+		}
+		return types.TokenPair{}, ErrRefreshTokenInvalid
+	}
+
+	user, err := service.userStore.GetUserByUID(uid)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	token, claims, err := generators.GenerateToken(service.keySet, uid, user.UserName, sessionID)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	newRefreshToken, newRefreshSecret, err := generators.GenerateRefreshToken(uid, sessionID)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	rotated, err := service.sessionStore.RotateSession(
+		uid,
+		sessionID,
+		session.RefreshHash,
+		encryptors.HashToken(newRefreshSecret),
+		claims.ID,
+	)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+	if !rotated {
+		return types.TokenPair{}, ErrRefreshTokenInvalid
+	}
+
+	err = service.userStore.BanUserToken(uid, session.AccessTokenID)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	err = service.userStore.CreateUserAvaliableToken(claims)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	return types.TokenPair{
+		AccessToken:  token,
+		RefreshToken: newRefreshToken,
+		ExpiresIn:    consts.TOKEN_EXPIRE_DURATION,
+	}, nil
+}
+
+func (service *UserService) LogoutUser(claims *types.BearerTokenClaims) error {
+
+	err := service.userStore.BanUserToken(claims.UID, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	if claims.SessionID == "" {
+		return nil
+	}
+
+	return service.sessionStore.DeleteSession(claims.UID, claims.SessionID)
+}
+
+func (service *UserService) GetLoginHistory(uid uint64, length string, from string) ([]models.UserLoginLog, error) {
+
+	var (
+		queryLength = consts.LOGIN_HISTORY_MAX_LENGTH
+		fromID      uint64
+		err         error
+	)
+	if length != "" {
+		queryLength, err = strconv.Atoi(length)
+		if err != nil {
+			return nil, err
+		}
+		if queryLength > consts.LOGIN_HISTORY_MAX_LENGTH {
+			queryLength = consts.LOGIN_HISTORY_MAX_LENGTH
+		}
+	}
+	if from != "" {
+		fromID, err = strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return service.userStore.GetUserLoginLogList(uid, fromID, queryLength)
+}
+
+func (service *UserService) GetSessionList(uid uint64) ([]types.UserSession, map[string]*models.UserLoginLog, error) {
+
+	sessions, err := service.sessionStore.GetSessionList(uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	loginLogs := make(map[string]*models.UserLoginLog, len(sessions))
+	for _, session := range sessions {
+		loginLog, err := service.userStore.GetUserLoginLogBySessionID(uid, session.SessionID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		loginLogs[session.SessionID] = loginLog
+	}
+
+	return sessions, loginLogs, nil
+}
+
+func (service *UserService) RevokeSession(uid uint64, sessionID string) error {
+
+	session, err := service.sessionStore.GetSession(uid, sessionID)
+	if errors.Is(err, redis.Nil) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return service.revokeSession(uid, *session)
+}
+
+func (service *UserService) revokeSession(uid uint64, session types.UserSession) error {
+
+	err := service.userStore.BanUserToken(uid, session.AccessTokenID)
+	if err != nil {
+		return err
+	}
+
+	return service.sessionStore.DeleteSession(uid, session.SessionID)
 }
 
 func (service *UserService) UserUploadAvatar(uid uint64, fileHeader *multipart.FileHeader) error {
@@ -168,7 +772,15 @@ func (service *UserService) UserUploadAvatar(uid uint64, fileHeader *multipart.F
 	return service.userStore.SaveUserAvatarByUID(uid, sb.String(), resizedAvatar)
 }
 
-func (service *UserService) UserUpdatePassword(username string, password string, newPassword string) error {
+func (service *UserService) UserUpdatePassword(username string, password string, newPassword string, ip string) error {
+
+	lockTTL, err := service.loginAttemptStore.GetLockTTL(username, ip)
+	if err != nil {
+		return err
+	}
+	if lockTTL > 0 {
+		return &LoginLockedError{RetryAfter: lockTTL}
+	}
 
 	userAuthInfo, err := service.userStore.GetUserAuthInfoByUsername(username)
 	if err != nil {
@@ -177,11 +789,14 @@ func (service *UserService) UserUpdatePassword(username string, password string,
 
 	err = encryptors.CompareHashPassword(userAuthInfo.PasswordHash, password, userAuthInfo.Salt)
 	if err != nil {
-
+		_, inner_err := service.loginAttemptStore.RecordFailure(username, ip)
+		if inner_err != nil {
+			return errors.Join(err, inner_err)
+		}
 		return errors.New("incorrect password")
 	}
 
-	hashedNewPassword, err := encryptors.HashPassword(newPassword, userAuthInfo.Salt)
+	hashedNewPassword, err := encryptors.HashPassword(newPassword)
 	if err != nil {
 		return err
 	}
@@ -219,5 +834,296 @@ func (service *UserService) UpdateUserInfo(uid uint64, reqBody *types.UserUpdate
 		return err
 	}
 
+	if reqBody.IsPrivate != nil {
+		err = service.userStore.UpdateUserPrivacyByUID(uid, *reqBody.IsPrivate)
+		if err != nil {
+			return err
+		}
+		if !*reqBody.IsPrivate {
+			err = service.followApprover.ApproveAll(uid)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (service *UserService) UnlockUser(operatorUID uint64, username string, ip string) error {
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	reason := "account unlocked by user"
+	if operatorUID != userAuthInfo.UID {
+		reason = "account unlocked by admin " + strconv.FormatUint(operatorUID, 10)
+	}
+
+	return service.unlockUser(userAuthInfo, ip, reason)
+}
+
+func (service *UserService) unlockUser(userAuthInfo *models.UserAuthInfo, ip string, reason string) error {
+
+	err := service.loginAttemptStore.Unlock(userAuthInfo.UserName)
+	if err != nil {
+		return err
+	}
+
+	return service.userStore.CreateUserLoginLog(&models.UserLoginLog{
+		UID:       userAuthInfo.UID,
+		LoginTime: time.Now(),
+		LoginIP:   ip,
+		IsSucceed: false,
+		IfChecked: true,
+		Reason:    reason,
+	})
+}
+
+func (service *UserService) RequestUnlock(username string) error {
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUsername(username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if userAuthInfo.Email == nil || !userAuthInfo.EmailVerified {
+		return nil
+	}
+
+	token, err := generators.GenerateSalt(consts.VERIFICATION_TOKEN_LENGTH)
+	if err != nil {
+		return err
+	}
+
+	err = service.verificationStore.CreateAccountUnlock(userAuthInfo.UID, encryptors.HashToken(token))
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("An unlock was requested for the account ")
+	sb.WriteString(userAuthInfo.UserName)
+	sb.WriteString(" after too many failed sign-in attempts.\n\nUse the following token to unlock it:\n\n")
+	sb.WriteString(token)
+	sb.WriteString("\n\nThe token can be used once and expires in 30 minutes. If you did not request this, consider changing your password.\n")
+
+	return service.mailer.Send(mailers.Message{
+		To:      *userAuthInfo.Email,
+		Subject: "Unlock your account",
+		Body:    sb.String(),
+	})
+}
+
+func (service *UserService) UnlockUserByToken(token string, ip string) error {
+
+	uid, err := service.verificationStore.ConsumeAccountUnlock(encryptors.HashToken(token))
+	if errors.Is(err, redis.Nil) {
+		return ErrVerificationTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUID(uid)
+	if err != nil {
+		return err
+	}
+
+	return service.unlockUser(userAuthInfo, ip, "account unlocked by user")
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (service *UserService) sendEmailVerification(uid uint64, email string) error {
+
+	token, err := generators.GenerateSalt(consts.VERIFICATION_TOKEN_LENGTH)
+	if err != nil {
+		return err
+	}
+
+	err = service.verificationStore.CreateEmailVerification(uid, email, encryptors.HashToken(token))
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Please confirm your email address by opening the link below:\n\n")
+	sb.WriteString(service.baseURL)
+	sb.WriteString("/api/user/email/verify?token=")
+	sb.WriteString(token)
+	sb.WriteString("\n\nThe link expires in 24 hours. If you did not request this, you can ignore this email.\n")
+
+	return service.mailer.Send(mailers.Message{
+		To:      email,
+		Subject: "Verify your email address",
+		Body:    sb.String(),
+	})
+}
+
+func (service *UserService) UpdateEmail(uid uint64, email string) error {
+
+	email = normalizeEmail(email)
+	if !validers.IsValidEmail(email) {
+		return errors.New("invalid email")
+	}
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByEmail(email)
+	if err == nil && userAuthInfo.UID != uid {
+		return ErrEmailAlreadyUsed
+	}
+	if err == nil {
+		return errors.New("email is already verified")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	err = service.userStore.UpdatePendingEmailByUID(uid, email)
+	if err != nil {
+		return err
+	}
+
+	return service.sendEmailVerification(uid, email)
+}
+
+func (service *UserService) ResendEmailVerification(uid uint64) error {
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUID(uid)
+	if err != nil {
+		return err
+	}
+	if userAuthInfo.PendingEmail == nil {
+		return errors.New("no email is pending verification")
+	}
+
+	return service.sendEmailVerification(uid, *userAuthInfo.PendingEmail)
+}
+
+func (service *UserService) VerifyEmail(token string) error {
+
+	uid, email, err := service.verificationStore.ConsumeEmailVerification(encryptors.HashToken(token))
+	if errors.Is(err, redis.Nil) {
+		return ErrVerificationTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByEmail(email)
+	if err == nil && userAuthInfo.UID != uid {
+		return ErrEmailAlreadyUsed
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	verified, err := service.userStore.VerifyUserEmailByUID(uid, email)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return ErrVerificationTokenInvalid
+	}
+
+	return nil
+}
+
+func (service *UserService) ForgotPassword(email string) error {
+
+	email = normalizeEmail(email)
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !userAuthInfo.EmailVerified {
+		return nil
+	}
+
+	token, err := generators.GenerateSalt(consts.VERIFICATION_TOKEN_LENGTH)
+	if err != nil {
+		return err
+	}
+
+	err = service.verificationStore.CreatePasswordReset(userAuthInfo.UID, encryptors.HashToken(token))
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("A password reset was requested for the account ")
+	sb.WriteString(userAuthInfo.UserName)
+	sb.WriteString(".\n\nUse the following token to choose a new password:\n\n")
+	sb.WriteString(token)
+	sb.WriteString("\n\nThe token can be used once and expires in 30 minutes. If you did not request this, you can ignore this email.\n")
+
+	return service.mailer.Send(mailers.Message{
+		To:      email,
+		Subject: "Reset your password",
+		Body:    sb.String(),
+	})
+}
+
+func (service *UserService) ResetPassword(token string, newPassword string) error {
+
+	if !validers.IsValidPassword(newPassword) {
+		return errors.New("invalid password")
+	}
+
+	uid, err := service.verificationStore.ConsumePasswordReset(encryptors.HashToken(token))
+	if errors.Is(err, redis.Nil) {
+		return ErrVerificationTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+
+	userAuthInfo, err := service.userStore.GetUserAuthInfoByUID(uid)
+	if err != nil {
+		return err
+	}
+
+	hashedNewPassword, err := encryptors.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	err = service.userStore.UpdateUserPasswordByUsername(userAuthInfo.UserName, hashedNewPassword)
+	if err != nil {
+		return err
+	}
+
+	err = service.loginAttemptStore.Unlock(userAuthInfo.UserName)
+	if err != nil {
+		return err
+	}
+
+	return service.revokeAllSessions(uid)
+}
+
+func (service *UserService) revokeAllSessions(uid uint64) error {
+
+	sessions, err := service.sessionStore.GetSessionList(uid)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		err = service.revokeSession(uid, session)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
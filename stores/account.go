@@ -0,0 +1,364 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type AccountStore struct {
+	db           *gorm.DB
+	rds          *redis.Client
+	mongo        *mongo.Client
+	userStore    *UserStore
+	sessionStore *SessionStore
+}
+
+func (factory *Factory) NewAccountStore() *AccountStore {
+	return &AccountStore{
+		db:           factory.db,
+		rds:          factory.rds,
+		mongo:        factory.mongo,
+		userStore:    factory.NewUserStore(),
+		sessionStore: factory.NewSessionStore(),
+	}
+}
+
+func (store *AccountStore) GetAccountDeletion(uid uint64) (*models.AccountDeletion, error) {
+	accountDeletion := new(models.AccountDeletion)
+	result := store.db.Where("uid = ?", uid).First(accountDeletion)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return accountDeletion, nil
+}
+
+func (store *AccountStore) ScheduleAccountDeletion(uid uint64, scheduledAt time.Time) (*models.AccountDeletion, error) {
+	accountDeletion := &models.AccountDeletion{
+		UID:         uid,
+		ScheduledAt: scheduledAt,
+	}
+	result := store.db.Clauses(clause.OnConflict{DoNothing: true}).Create(accountDeletion)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return store.GetAccountDeletion(uid)
+}
+
+func (store *AccountStore) CancelAccountDeletion(uid uint64) error {
+	result := store.db.Unscoped().Where("uid = ?", uid).Delete(&models.AccountDeletion{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (store *AccountStore) GetDueAccountDeletions(now time.Time, length int) ([]models.AccountDeletion, error) {
+	var accountDeletions []models.AccountDeletion
+	result := store.db.Where("scheduled_at <= ?", now).Order("scheduled_at ASC").Limit(length).Find(&accountDeletions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return accountDeletions, nil
+}
+
+func (store *AccountStore) ExportAccount(uid uint64) (*models.AccountArchive, error) {
+	archive := new(models.AccountArchive)
+
+	result := store.db.Where("id = ?", uid).First(&archive.User)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	result = store.db.Where("uid = ?", uid).First(&archive.AuthInfo)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	result = store.db.Where("uid = ?", uid).Order("id ASC").Find(&archive.Posts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	result = store.db.Where("uid = ?", uid).Order("id ASC").Find(&archive.Comments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	result = store.db.Where("uid = ?", uid).Order("id ASC").Find(&archive.Replies)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	result = store.db.Where("uid = ?", uid).Order("id ASC").Find(&archive.LoginLogs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	database := store.mongo.Database(consts.MONGODB_DATABASE_NAME)
+	err := findAll(database.Collection(consts.POST_LIKE_COLLECTION), bson.M{"uid": uid}, &archive.Likes)
+	if err != nil {
+		return nil, err
+	}
+	err = findAll(database.Collection(consts.POST_FAVORITE_COLLECTION), bson.M{"uid": uid}, &archive.Favourites)
+	if err != nil {
+		return nil, err
+	}
+	err = findAll(database.Collection(consts.COMMENT_RATE_COLLECTION), bson.M{"uid": uid}, &archive.CommentRates)
+	if err != nil {
+		return nil, err
+	}
+	err = findAll(database.Collection(consts.POST_FORWARD_COLLECTION), bson.M{"uid": uid}, &archive.Forwards)
+	if err != nil {
+		return nil, err
+	}
+	err = findAll(database.Collection(consts.FOLLOW_RECORD_COLLECTION), bson.M{"uid": uid}, &archive.Followings)
+	if err != nil {
+		return nil, err
+	}
+	err = findAll(database.Collection(consts.FOLLOW_RECORD_COLLECTION), bson.M{"followed_id": uid}, &archive.Followers)
+	if err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func findAll(collection *mongo.Collection, filter interface{}, results interface{}, opts ...*options.FindOptions) error {
+	cur, err := collection.Find(context.Background(), filter, opts...)
+	if err != nil {
+		return err
+	}
+	return cur.All(context.Background(), results)
+}
+
+func (store *AccountStore) DeleteAccount(uid uint64) error {
+	ctx := context.Background()
+
+	user := new(models.UserInfo)
+	result := store.db.Where("id = ?", uid).First(user)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	var posts []models.PostInfo
+	result = store.db.Unscoped().Where("uid = ?", uid).Find(&posts)
+	if result.Error != nil {
+		return result.Error
+	}
+	postIDs := make([]uint64, len(posts))
+	postIDs64 := make([]int64, len(posts))
+	for index, post := range posts {
+		postIDs[index] = uint64(post.ID)
+		postIDs64[index] = int64(post.ID)
+	}
+
+	commentIDs := make([]uint64, 0)
+	result = store.db.Unscoped().Model(&models.CommentInfo{}).
+		Where("uid = ? OR post_id IN ?", uid, postIDs).
+		Pluck("id", &commentIDs)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	replyIDs := make([]uint64, 0)
+	result = store.db.Unscoped().Model(&models.ReplyInfo{}).
+		Where("uid = ? OR comment_id IN ?", uid, commentIDs).
+		Pluck("id", &replyIDs)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	var followers []models.FollowInfo
+	database := store.mongo.Database(consts.MONGODB_DATABASE_NAME)
+	err := findAll(database.Collection(consts.FOLLOW_RECORD_COLLECTION), bson.M{"followed_id": uid}, &followers)
+	if err != nil {
+		return err
+	}
+
+	err = store.deleteAccountKeys(uid, postIDs, followers)
+	if err != nil {
+		return err
+	}
+
+	deletions := []struct {
+		collection string
+		filter     bson.M
+	}{
+		{consts.POST_LIKE_COLLECTION, bson.M{"$or": bson.A{bson.M{"uid": uid}, bson.M{"post_id": bson.M{"$in": postIDs64}}}}},
+		{consts.POST_FAVORITE_COLLECTION, bson.M{"$or": bson.A{bson.M{"uid": uid}, bson.M{"post_id": bson.M{"$in": postIDs64}}}}},
+		{consts.COMMENT_RATE_COLLECTION, bson.M{"$or": bson.A{bson.M{"uid": uid}, bson.M{"comment_id": bson.M{"$in": commentIDs}}}}},
+		{consts.FOLLOW_RECORD_COLLECTION, bson.M{"$or": bson.A{bson.M{"uid": uid}, bson.M{"followed_id": uid}}}},
+		{consts.FOLLOW_REQUEST_COLLECTION, bson.M{"$or": bson.A{bson.M{"uid": uid}, bson.M{"followed_id": uid}}}},
+		{consts.BLOCK_RECORD_COLLECTION, bson.M{"$or": bson.A{bson.M{"uid": uid}, bson.M{"target_id": uid}}}},
+		{consts.MUTE_RECORD_COLLECTION, bson.M{"$or": bson.A{bson.M{"uid": uid}, bson.M{"target_id": uid}}}},
+		{consts.POST_FORWARD_COLLECTION, bson.M{"$or": bson.A{
+			bson.M{"uid": uid},
+			bson.M{"post_id": bson.M{"$in": postIDs64}},
+			bson.M{"quote_post_id": bson.M{"$in": postIDs64}},
+		}}},
+		{consts.NOTIFICATION_COLLECTION, bson.M{"uid": uid}},
+	}
+	for _, deletion := range deletions {
+		_, err = database.Collection(deletion.collection).DeleteMany(ctx, deletion.filter)
+		if err != nil {
+			return err
+		}
+	}
+
+	notificationCollection := database.Collection(consts.NOTIFICATION_COLLECTION)
+	_, err = notificationCollection.UpdateMany(ctx, bson.M{"actor_ids": uid}, bson.M{"$pull": bson.M{"actor_ids": uid}})
+	if err != nil {
+		return err
+	}
+	_, err = notificationCollection.DeleteMany(ctx, bson.M{"actor_ids": bson.M{"$size": 0}})
+	if err != nil {
+		return err
+	}
+
+	err = store.deleteAccountFiles(user, posts)
+	if err != nil {
+		return err
+	}
+
+	return store.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Unscoped().
+			Where("(source_type = ? AND source_id IN ?) OR (source_type = ? AND source_id IN ?) OR (source_type = ? AND source_id IN ?)",
+				types.CONTENT_SOURCE_POST, postIDs,
+				types.CONTENT_SOURCE_COMMENT, commentIDs,
+				types.CONTENT_SOURCE_REPLY, replyIDs,
+			).
+			Delete(&models.ContentEntity{}); result.Error != nil {
+			return result.Error
+		}
+		if result := tx.Model(&models.ContentEntity{}).Where("mention_uid = ?", uid).Update("mention_uid", nil); result.Error != nil {
+			return result.Error
+		}
+
+		if result := tx.Unscoped().Where("id IN ?", replyIDs).Delete(&models.ReplyInfo{}); result.Error != nil {
+			return result.Error
+		}
+		if result := tx.Unscoped().Where("id IN ?", commentIDs).Delete(&models.CommentInfo{}); result.Error != nil {
+			return result.Error
+		}
+		if result := tx.Model(&models.PostInfo{}).Where("parent_post_id IN ?", postIDs).Update("parent_post_id", nil); result.Error != nil {
+			return result.Error
+		}
+		if result := tx.Unscoped().Where("uid = ?", uid).Delete(&models.PostInfo{}); result.Error != nil {
+			return result.Error
+		}
+		if err := createOutboxEvents(tx, types.OUTBOX_EVENT_POST_INDEX_DELETE, postIDs...); err != nil {
+			return err
+		}
+		if err := createOutboxEvents(tx, types.OUTBOX_EVENT_COMMENT_INDEX_DELETE, commentIDs...); err != nil {
+			return err
+		}
+		if err := createOutboxEvents(tx, types.OUTBOX_EVENT_USER_INDEX_DELETE, uid); err != nil {
+			return err
+		}
+
+		for _, column := range []string{"like", "favourite", "farward"} {
+			if result := tx.Model(&models.PostInfo{}).Where("? = ANY(?)", uid, clause.Column{Name: column}).
+				Update(column, gorm.Expr("array_remove(?, ?)", clause.Column{Name: column}, uid)); result.Error != nil {
+				return result.Error
+			}
+		}
+		for _, column := range []string{"like", "dislike"} {
+			if result := tx.Model(&models.CommentInfo{}).Where("? = ANY(?)", uid, clause.Column{Name: column}).
+				Update(column, gorm.Expr("array_remove(?, ?)", clause.Column{Name: column}, uid)); result.Error != nil {
+				return result.Error
+			}
+			if result := tx.Model(&models.ReplyInfo{}).Where("? = ANY(?)", uid, clause.Column{Name: column}).
+				Update(column, gorm.Expr("array_remove(?, ?)", clause.Column{Name: column}, uid)); result.Error != nil {
+				return result.Error
+			}
+		}
+
+		for _, model := range []interface{}{
+			&models.UserPostStatus{},
+			&models.UserCommentStatus{},
+			&models.UserLoginLog{},
+			&models.UserAuthInfo{},
+		} {
+			if result := tx.Unscoped().Where("uid = ?", uid).Delete(model); result.Error != nil {
+				return result.Error
+			}
+		}
+
+		if result := tx.Unscoped().Where("id = ?", uid).Delete(&models.UserInfo{}); result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Unscoped().Where("uid = ?", uid).Delete(&models.AccountDeletion{}).Error
+	})
+}
+
+func (store *AccountStore) deleteAccountKeys(uid uint64, postIDs []uint64, followers []models.FollowInfo) error {
+	ctx := context.Background()
+
+	sessions, err := store.sessionStore.GetSessionList(uid)
+	if err != nil {
+		return err
+	}
+	tokenIDs, err := store.rds.ZRange(ctx, store.userStore.userTokenIndexKey(uid), 0, -1).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := store.rds.TxPipeline()
+	for _, session := range sessions {
+		pipe.Del(ctx, store.sessionStore.sessionKey(uid, session.SessionID))
+	}
+	pipe.Del(ctx, store.sessionStore.sessionIndexKey(uid))
+	for _, tokenID := range tokenIDs {
+		pipe.Del(ctx, store.userStore.userTokenKey(uid, tokenID))
+	}
+	pipe.Del(ctx, store.userStore.userTokenIndexKey(uid))
+	pipe.Del(ctx, timelineKey(uid))
+	pipe.SRem(ctx, consts.REDIS_TIMELINE_CELEBRITY_SET, uid)
+	if len(postIDs) > 0 {
+		members := make([]interface{}, len(postIDs))
+		for index, postID := range postIDs {
+			members[index] = postID
+		}
+		for _, follower := range followers {
+			pipe.ZRem(ctx, timelineKey(follower.UserID), members...)
+		}
+	}
+	_, err = pipe.Exec(ctx)
+
+	return err
+}
+
+func (store *AccountStore) deleteAccountFiles(user *models.UserInfo, posts []models.PostInfo) error {
+	for _, post := range posts {
+		for _, image := range post.Images {
+			err := os.Remove(filepath.Join(consts.POST_IMAGE_PATH, image))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	if user.Avatar == "vanilla.webp" {
+		return nil
+	}
+
+	return store.rds.XAdd(context.Background(), &redis.XAddArgs{
+		Stream: consts.AVATAR_CLEAN_STREAM,
+		Values: map[string]interface{}{
+			"filename": user.Avatar,
+		},
+	}).Err()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/lib/pq"
 	"github.com/mehakhanaa/complex-micro-blog/consts"
 	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -37,9 +38,14 @@ func (store *CommentStore) CreateComment(uid uint64, username string, postID uin
 		IsPublic: true,
 	}
 
-	result := store.db.Create(&newComment)
-	if result.Error != nil {
-		return 0, result.Error
+	err := store.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Create(&newComment); result.Error != nil {
+			return result.Error
+		}
+		return createOutboxEvents(tx, types.OUTBOX_EVENT_COMMENT_INDEX_CREATE, uint64(newComment.ID))
+	})
+	if err != nil {
+		return 0, err
 	}
 	return uint64(newComment.ID), nil
 }
@@ -65,15 +71,21 @@ func (store *CommentStore) UpdateComment(commentID uint64, content string) error
 	}
 
 	commentInfo.Content = content
-	result = store.db.Save(commentInfo)
-	if result.Error != nil {
-		return result.Error
-	}
-	return nil
+	return store.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Save(commentInfo); result.Error != nil {
+			return result.Error
+		}
+		return createOutboxEvents(tx, types.OUTBOX_EVENT_COMMENT_INDEX_UPDATE, commentID)
+	})
 }
 
 func (store *CommentStore) DeleteComment(commentID uint64) error {
-	return store.db.Where("id = ?", commentID).Unscoped().Delete(&models.CommentInfo{}).Error
+	return store.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Where("id = ?", commentID).Unscoped().Delete(&models.CommentInfo{}); result.Error != nil {
+			return result.Error
+		}
+		return createOutboxEvents(tx, types.OUTBOX_EVENT_COMMENT_INDEX_DELETE, commentID)
+	})
 }
 
 func (store *CommentStore) GetCommentList(postID uint64) ([]models.CommentInfo, error) {
@@ -85,6 +97,56 @@ func (store *CommentStore) GetCommentList(postID uint64) ([]models.CommentInfo,
 	return commentInfos, nil
 }
 
+func (store *CommentStore) GetComment(commentID uint64) (models.CommentInfo, error) {
+	comment := models.CommentInfo{}
+	result := store.db.Where("id = ?", commentID).First(&comment)
+	if result.Error != nil {
+		return models.CommentInfo{}, result.Error
+	}
+	return comment, nil
+}
+
+func (store *CommentStore) GetCommentUIDs(commentIDs []int64) (map[int64]uint64, error) {
+	var comments []models.CommentInfo
+	if result := store.db.Select("id", "uid").Where("id IN ?", commentIDs).Find(&comments); result.Error != nil {
+		return nil, result.Error
+	}
+	commentUIDs := make(map[int64]uint64, len(comments))
+	for _, comment := range comments {
+		commentUIDs[int64(comment.ID)] = comment.UID
+	}
+	return commentUIDs, nil
+}
+
+func (store *CommentStore) GetCommentPostUIDs(commentIDs []int64) (map[int64]uint64, error) {
+	var rows []struct {
+		ID  int64
+		UID uint64
+	}
+	result := store.db.Model(&models.CommentInfo{}).
+		Select("comment_infos.id AS id, post_infos.uid AS uid").
+		Joins("JOIN post_infos ON post_infos.id = comment_infos.post_id AND post_infos.deleted_at IS NULL").
+		Where("comment_infos.id IN ?", commentIDs).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	postUIDs := make(map[int64]uint64, len(rows))
+	for _, row := range rows {
+		postUIDs[row.ID] = row.UID
+	}
+	return postUIDs, nil
+}
+
+func (store *CommentStore) GetCommentCount(postID uint64) (int64, error) {
+	var count int64
+	result := store.db.Model(&models.CommentInfo{}).Where("post_id = ?", postID).Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
 func (store *CommentStore) GetCommentInfo(commentID uint64) (models.CommentInfo, int64, error) {
 	comment := models.CommentInfo{}
 	result := store.db.Where("id = ?", commentID).First(&comment)
@@ -0,0 +1,83 @@
+package stores
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type EntityStore struct {
+	db *gorm.DB
+}
+
+func (factory *Factory) NewEntityStore() *EntityStore {
+	return &EntityStore{factory.db}
+}
+
+func (store *EntityStore) ReplaceEntities(sourceType types.ContentSourceType, sourceID uint64, entities []models.ContentEntity) error {
+	tx := store.db.Begin()
+
+	result := tx.Where("source_type = ? AND source_id = ?", sourceType, sourceID).Unscoped().Delete(&models.ContentEntity{})
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+
+	if len(entities) > 0 {
+		for index := range entities {
+			entities[index].SourceType = sourceType
+			entities[index].SourceID = sourceID
+		}
+		result = tx.Create(&entities)
+		if result.Error != nil {
+			tx.Rollback()
+			return result.Error
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (store *EntityStore) DeleteEntities(sourceType types.ContentSourceType, sourceID uint64) error {
+	return store.db.Where("source_type = ? AND source_id = ?", sourceType, sourceID).Unscoped().Delete(&models.ContentEntity{}).Error
+}
+
+func (store *EntityStore) GetEntities(sourceType types.ContentSourceType, sourceID uint64) ([]models.ContentEntity, error) {
+	var entities []models.ContentEntity
+	result := store.db.Where("source_type = ? AND source_id = ?", sourceType, sourceID).Order("text_offset asc").Find(&entities)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entities, nil
+}
+
+func (store *EntityStore) GetPostIDsByHashtag(tag string, from string, length int) ([]int64, error) {
+	var postIDs []int64
+	query := store.db.Model(&models.ContentEntity{}).
+		Distinct("source_id").
+		Where("source_type = ? AND type = ? AND text = ?", types.CONTENT_SOURCE_POST, types.CONTENT_ENTITY_HASHTAG, tag)
+	if from != "" {
+		query = query.Where("source_id < ?", from)
+	}
+	result := query.Order("source_id desc").Limit(length).Pluck("source_id", &postIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return postIDs, nil
+}
+
+func (store *EntityStore) GetPostIDsByMention(uid uint64, from string, length int) ([]int64, error) {
+	var postIDs []int64
+	query := store.db.Model(&models.ContentEntity{}).
+		Distinct("source_id").
+		Where("source_type = ? AND type = ? AND mention_uid = ?", types.CONTENT_SOURCE_POST, types.CONTENT_ENTITY_MENTION, uid)
+	if from != "" {
+		query = query.Where("source_id < ?", from)
+	}
+	result := query.Order("source_id desc").Limit(length).Pluck("source_id", &postIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return postIDs, nil
+}
@@ -105,3 +105,64 @@ func (store *FollowStore) GetFollowersByUID(uid uint64) (int64, error) {
 	}
 	return store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.FOLLOW_RECORD_COLLECTION).CountDocuments(context.Background(), filter)
 }
+
+func (store *FollowStore) IsFollowing(uid, followedID uint64) (bool, error) {
+	filter := bson.D{
+		{Key: "uid", Value: uid},
+		{Key: "followed_id", Value: followedID},
+	}
+	count, err := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.FOLLOW_RECORD_COLLECTION).CountDocuments(context.Background(), filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (store *FollowStore) CreateFollowRequest(uid, followedID uint64) error {
+
+	filter := bson.D{
+		{Key: "uid", Value: uid},
+		{Key: "followed_id", Value: followedID},
+	}
+
+	update := bson.D{
+		{Key: "$setOnInsert", Value: bson.D{
+			{Key: "requested_at", Value: time.Now()},
+		}},
+	}
+
+	followRequestCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.FOLLOW_REQUEST_COLLECTION)
+	_, err := followRequestCollection.UpdateOne(context.Background(), filter, update, options.Update().SetUpsert(true))
+
+	return err
+}
+
+func (store *FollowStore) DeleteFollowRequest(uid, followedID uint64) (bool, error) {
+	filter := bson.D{
+		{Key: "uid", Value: uid},
+		{Key: "followed_id", Value: followedID},
+	}
+
+	followRequestCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.FOLLOW_REQUEST_COLLECTION)
+	result, err := followRequestCollection.DeleteOne(context.Background(), filter)
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (store *FollowStore) GetFollowRequestList(followedID uint64) ([]models.FollowRequestInfo, error) {
+	var followRequests []models.FollowRequestInfo
+	filter := bson.M{
+		"followed_id": followedID,
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "requested_at", Value: -1}})
+	cur, err := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.FOLLOW_REQUEST_COLLECTION).Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := cur.All(context.Background(), &followRequests); err != nil {
+		return nil, err
+	}
+	return followRequests, nil
+}
@@ -0,0 +1,147 @@
+package stores
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+)
+
+var loginFailureScript = redis.NewScript(`
+local failures = redis.call("INCR", KEYS[1])
+if failures == 1 then
+	redis.call("EXPIRE", KEYS[1], ARGV[2])
+end
+if failures >= tonumber(ARGV[1]) then
+	local strikes = redis.call("INCR", KEYS[3])
+	redis.call("EXPIRE", KEYS[3], ARGV[7])
+	local lock = math.floor(tonumber(ARGV[3]) * 2 ^ (strikes - 1))
+	if lock > tonumber(ARGV[4]) then
+		lock = tonumber(ARGV[4])
+	end
+	redis.call("SET", KEYS[2], 1, "EX", lock)
+	redis.call("DEL", KEYS[1], KEYS[4])
+	return lock
+end
+if failures > tonumber(ARGV[5]) then
+	local delay = math.floor(2 ^ (failures - tonumber(ARGV[5]) - 1))
+	if delay > tonumber(ARGV[6]) then
+		delay = tonumber(ARGV[6])
+	end
+	redis.call("SET", KEYS[4], 1, "EX", delay)
+	return delay
+end
+return 0
+`)
+
+const (
+	loginScopeUser = "USER"
+	loginScopeIP   = "IP"
+)
+
+type LoginAttemptStore struct {
+	rds *redis.Client
+}
+
+func (factory *Factory) NewLoginAttemptStore() *LoginAttemptStore {
+	return &LoginAttemptStore{factory.rds}
+}
+
+func (store *LoginAttemptStore) loginKey(prefix, scope, subject string) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteRune(':')
+	sb.WriteString(scope)
+	sb.WriteRune(':')
+	sb.WriteString(subject)
+	return sb.String()
+}
+
+func (store *LoginAttemptStore) GetLockTTL(username, ip string) (time.Duration, error) {
+	ctx := context.Background()
+
+	pipe := store.rds.Pipeline()
+	cmds := []*redis.DurationCmd{
+		pipe.TTL(ctx, store.loginKey(consts.REDIS_LOGIN_LOCK, loginScopeUser, username)),
+		pipe.TTL(ctx, store.loginKey(consts.REDIS_LOGIN_DELAY, loginScopeUser, username)),
+		pipe.TTL(ctx, store.loginKey(consts.REDIS_LOGIN_LOCK, loginScopeIP, ip)),
+		pipe.TTL(ctx, store.loginKey(consts.REDIS_LOGIN_DELAY, loginScopeIP, ip)),
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var lockTTL time.Duration
+	for _, cmd := range cmds {
+		if cmd.Val() > lockTTL {
+			lockTTL = cmd.Val()
+		}
+	}
+
+	return lockTTL, nil
+}
+
+func (store *LoginAttemptStore) recordFailure(scope, subject string, maxFailures int) (time.Duration, error) {
+	seconds, err := loginFailureScript.Run(
+		context.Background(),
+		store.rds,
+		[]string{
+			store.loginKey(consts.REDIS_LOGIN_FAILURE, scope, subject),
+			store.loginKey(consts.REDIS_LOGIN_LOCK, scope, subject),
+			store.loginKey(consts.REDIS_LOGIN_STRIKE, scope, subject),
+			store.loginKey(consts.REDIS_LOGIN_DELAY, scope, subject),
+		},
+		maxFailures,
+		consts.LOGIN_FAILURE_WINDOW,
+		consts.LOGIN_LOCK_BASE_DURATION,
+		consts.LOGIN_LOCK_MAX_DURATION,
+		consts.LOGIN_FREE_ATTEMPTS,
+		consts.LOGIN_MAX_DELAY,
+		consts.LOGIN_LOCK_STRIKE_WINDOW,
+	).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func (store *LoginAttemptStore) RecordFailure(username, ip string) (time.Duration, error) {
+
+	userLockTTL, err := store.recordFailure(loginScopeUser, username, consts.LOGIN_MAX_FAILURES_PER_USER)
+	if err != nil {
+		return 0, err
+	}
+
+	ipLockTTL, err := store.recordFailure(loginScopeIP, ip, consts.LOGIN_MAX_FAILURES_PER_IP)
+	if err != nil {
+		return 0, err
+	}
+
+	if ipLockTTL > userLockTTL {
+		return ipLockTTL, nil
+	}
+	return userLockTTL, nil
+}
+
+func (store *LoginAttemptStore) ResetFailures(username string) error {
+	return store.rds.Del(
+		context.Background(),
+		store.loginKey(consts.REDIS_LOGIN_FAILURE, loginScopeUser, username),
+		store.loginKey(consts.REDIS_LOGIN_DELAY, loginScopeUser, username),
+	).Err()
+}
+
+func (store *LoginAttemptStore) Unlock(username string) error {
+	return store.rds.Del(
+		context.Background(),
+		store.loginKey(consts.REDIS_LOGIN_FAILURE, loginScopeUser, username),
+		store.loginKey(consts.REDIS_LOGIN_LOCK, loginScopeUser, username),
+		store.loginKey(consts.REDIS_LOGIN_STRIKE, loginScopeUser, username),
+		store.loginKey(consts.REDIS_LOGIN_DELAY, loginScopeUser, username),
+	).Err()
+}
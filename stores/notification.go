@@ -0,0 +1,162 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type NotificationStore struct {
+	rds   *redis.Client
+	mongo *mongo.Client
+}
+
+func (factory *Factory) NewNotificationStore() *NotificationStore {
+	return &NotificationStore{
+		rds:   factory.rds,
+		mongo: factory.mongo,
+	}
+}
+
+func (store *NotificationStore) CreateNotification(uid, actorID uint64, notificationType types.NotificationType, targetType types.NotificationTargetType, targetID uint64) error {
+
+	if uid == actorID {
+		return nil
+	}
+
+	filter := bson.D{
+		{Key: "uid", Value: uid},
+		{Key: "type", Value: notificationType},
+		{Key: "target_type", Value: targetType},
+		{Key: "target_id", Value: targetID},
+		{Key: "is_read", Value: false},
+	}
+
+	now := time.Now()
+	update := bson.D{
+		{Key: "$addToSet", Value: bson.D{
+			{Key: "actor_ids", Value: actorID},
+		}},
+		{Key: "$set", Value: bson.D{
+			{Key: "updated_at", Value: now},
+		}},
+		{Key: "$setOnInsert", Value: bson.D{
+			{Key: "created_at", Value: now},
+		}},
+	}
+
+	notificationCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.NOTIFICATION_COLLECTION)
+	_, err := notificationCollection.UpdateOne(context.Background(), filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+
+	return publishStreamEvent(store.rds, userStreamChannel(uid), types.StreamEvent{
+		Type: types.STREAM_EVENT_NOTIFICATION,
+		Data: types.NotificationStreamData{
+			Type:       notificationType,
+			TargetType: targetType,
+			TargetID:   targetID,
+			ActorID:    actorID,
+		},
+	})
+}
+
+func (store *NotificationStore) GetNotificationList(uid uint64, fromMilli int64, fromID primitive.ObjectID, length int) ([]models.NotificationInfo, error) {
+
+	filter := bson.D{{Key: "uid", Value: uid}}
+	if fromMilli != 0 && fromID.IsZero() {
+		filter = append(filter, bson.E{Key: "updated_at", Value: bson.D{
+			{Key: "$lt", Value: time.UnixMilli(fromMilli)},
+		}})
+	} else if fromMilli != 0 {
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "updated_at", Value: bson.D{{Key: "$lt", Value: time.UnixMilli(fromMilli)}}}},
+			bson.D{
+				{Key: "updated_at", Value: time.UnixMilli(fromMilli)},
+				{Key: "_id", Value: bson.D{{Key: "$lt", Value: fromID}}},
+			},
+		}})
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(length))
+
+	ctx := context.Background()
+	notificationCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.NOTIFICATION_COLLECTION)
+	cursor, err := notificationCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var notifications []models.NotificationInfo
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (store *NotificationStore) GetUnreadCount(uid uint64) (int64, error) {
+
+	filter := bson.D{
+		{Key: "uid", Value: uid},
+		{Key: "is_read", Value: false},
+	}
+
+	notificationCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.NOTIFICATION_COLLECTION)
+	return notificationCollection.CountDocuments(context.Background(), filter)
+}
+
+func (store *NotificationStore) MarkNotificationRead(uid uint64, notificationID primitive.ObjectID) error {
+
+	filter := bson.D{
+		{Key: "_id", Value: notificationID},
+		{Key: "uid", Value: uid},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "is_read", Value: true},
+		}},
+	}
+
+	notificationCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.NOTIFICATION_COLLECTION)
+	result, err := notificationCollection.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("notification does not exist")
+	}
+
+	return nil
+}
+
+func (store *NotificationStore) MarkAllNotificationsRead(uid uint64) error {
+
+	filter := bson.D{
+		{Key: "uid", Value: uid},
+		{Key: "is_read", Value: false},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "is_read", Value: true},
+		}},
+	}
+
+	notificationCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.NOTIFICATION_COLLECTION)
+	_, err := notificationCollection.UpdateMany(context.Background(), filter, update)
+
+	return err
+}
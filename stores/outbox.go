@@ -0,0 +1,115 @@
+package stores
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type OutboxStore struct {
+	db *gorm.DB
+}
+
+func (factory *Factory) NewOutboxStore() *OutboxStore {
+	return &OutboxStore{
+		db: factory.db,
+	}
+}
+
+func createOutboxEvents(tx *gorm.DB, eventType types.OutboxEventType, aggregateIDs ...uint64) error {
+
+	if len(aggregateIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	events := make([]models.OutboxEvent, len(aggregateIDs))
+	for index, aggregateID := range aggregateIDs {
+		events[index] = models.OutboxEvent{
+			Type:          eventType,
+			AggregateID:   aggregateID,
+			NextAttemptAt: now,
+		}
+	}
+
+	return tx.Create(&events).Error
+}
+
+func (store *OutboxStore) CreateOutboxEvents(eventType types.OutboxEventType, aggregateIDs ...uint64) error {
+	return createOutboxEvents(store.db, eventType, aggregateIDs...)
+}
+
+func (store *OutboxStore) ClaimOutboxEvents(limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	now := time.Now()
+
+	var events []models.OutboxEvent
+	result := store.db.Raw(
+		`UPDATE outbox_events SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE deleted_at IS NULL AND dead_at IS NULL AND next_attempt_at <= ?
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), now, limit,
+	).Scan(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return events, nil
+}
+
+func (store *OutboxStore) CompleteOutboxEvent(eventID uint) error {
+	return store.db.Unscoped().Where("id = ?", eventID).Delete(&models.OutboxEvent{}).Error
+}
+
+func (store *OutboxStore) RetryOutboxEvent(eventID uint, attempts int, nextAttemptAt time.Time, lastError string) error {
+	return store.db.Model(&models.OutboxEvent{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+	}).Error
+}
+
+func (store *OutboxStore) MarkOutboxEventDead(eventID uint, attempts int, lastError string) error {
+	return store.db.Model(&models.OutboxEvent{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+		"attempts":   attempts,
+		"last_error": lastError,
+		"dead_at":    time.Now(),
+	}).Error
+}
+
+func (store *OutboxStore) GetOutboxLag() (int64, int64, int64, *time.Time, error) {
+	var lag struct {
+		Pending  int64
+		Retrying int64
+		Dead     int64
+		Oldest   *time.Time
+	}
+	result := store.db.Model(&models.OutboxEvent{}).
+		Select(`COUNT(*) FILTER (WHERE dead_at IS NULL) AS pending,
+			COUNT(*) FILTER (WHERE dead_at IS NULL AND attempts > 0) AS retrying,
+			COUNT(*) FILTER (WHERE dead_at IS NOT NULL) AS dead,
+			MIN(created_at) FILTER (WHERE dead_at IS NULL) AS oldest`).
+		Scan(&lag)
+	if result.Error != nil {
+		return 0, 0, 0, nil, result.Error
+	}
+
+	return lag.Pending, lag.Retrying, lag.Dead, lag.Oldest, nil
+}
+
+func (store *OutboxStore) GetDeadOutboxEvents(length int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	result := store.db.Where("dead_at IS NOT NULL").Order("dead_at DESC").Limit(length).Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return events, nil
+}
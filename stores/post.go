@@ -37,20 +37,85 @@ func (factory *Factory) NewPostStore() *PostStore {
 	}
 }
 
-func (store *PostStore) GetPostList(from string, length int) ([]models.PostInfo, error) {
+func (store *PostStore) GetPostList(from string, length int, viewerUID uint64, followedIDs []uint64, excludedUIDs []uint64) ([]models.PostInfo, error) {
 	var posts []models.PostInfo
+	privateUIDs := store.db.Model(&models.UserInfo{}).Select("id").Where("is_private = ?", true)
+	query := store.db.Where("uid NOT IN (?) OR uid = ? OR uid IN ?", privateUIDs, viewerUID, followedIDs)
+	if len(excludedUIDs) > 0 {
+		query = query.Where("uid NOT IN ?", excludedUIDs)
+	}
 	if from != "" {
-		if result := store.db.Where("id < ?", from).Order("id desc").Limit(length).Find(&posts); result.Error != nil {
+		if result := query.Where("id < ?", from).Order("id desc").Limit(length).Find(&posts); result.Error != nil {
 			return nil, result.Error
 		}
 		return posts, nil
 	}
-	if result := store.db.Order("id desc").Limit(length).Find(&posts); result.Error != nil {
+	if result := query.Order("id desc").Limit(length).Find(&posts); result.Error != nil {
+		return nil, result.Error
+	}
+	return posts, nil
+}
+
+func (store *PostStore) GetPostUIDs(postIDs []int64) (map[int64]uint64, error) {
+	var posts []models.PostInfo
+	if result := store.db.Select("id", "uid").Where("id IN ?", postIDs).Find(&posts); result.Error != nil {
+		return nil, result.Error
+	}
+	postUIDs := make(map[int64]uint64, len(posts))
+	for _, post := range posts {
+		postUIDs[int64(post.ID)] = post.UID
+	}
+	return postUIDs, nil
+}
+
+func (store *PostStore) GetPostsAfterID(afterID uint64, limit int) ([]models.PostInfo, error) {
+	var posts []models.PostInfo
+	if result := store.db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&posts); result.Error != nil {
+		return nil, result.Error
+	}
+	return posts, nil
+}
+
+func (store *PostStore) GetPostIDBounds() (uint64, uint64, error) {
+	var bounds struct {
+		MinID uint64
+		MaxID uint64
+	}
+	result := store.db.Model(&models.PostInfo{}).Select("COALESCE(MIN(id), 0) AS min_id, COALESCE(MAX(id), 0) AS max_id").Scan(&bounds)
+	if result.Error != nil {
+		return 0, 0, result.Error
+	}
+	return bounds.MinID, bounds.MaxID, nil
+}
+
+func (store *PostStore) GetPostsInIDRange(startID, endID uint64) ([]models.PostInfo, error) {
+	var posts []models.PostInfo
+	if result := store.db.Where("id BETWEEN ? AND ?", startID, endID).Order("id").Find(&posts); result.Error != nil {
 		return nil, result.Error
 	}
 	return posts, nil
 }
 
+func (store *PostStore) GetPostUpdatedAts(postIDs []uint64) (map[uint64]time.Time, error) {
+	var posts []models.PostInfo
+	if result := store.db.Select("id", "updated_at").Where("id IN ?", postIDs).Find(&posts); result.Error != nil {
+		return nil, result.Error
+	}
+	updatedAts := make(map[uint64]time.Time, len(posts))
+	for _, post := range posts {
+		updatedAts[uint64(post.ID)] = post.UpdatedAt
+	}
+	return updatedAts, nil
+}
+
+func (store *PostStore) GetPostCount() (int64, error) {
+	var count int64
+	if result := store.db.Model(&models.PostInfo{}).Count(&count); result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
 func (store *PostStore) GetPostListByUID(uid string) ([]models.PostInfo, error) {
 	var userPosts []models.PostInfo
 	if result := store.db.Where("uid = ?", uid).Order("id desc").Find(&userPosts); result.Error != nil {
@@ -72,28 +137,42 @@ func (store *PostStore) ValidatePostExistence(postID uint64) (bool, error) {
 	return true, nil
 }
 
-func (store *PostStore) GetPostInfo(postID uint64) (models.PostInfo, int64, int64, error) {
+func (store *PostStore) GetPost(postID uint64) (models.PostInfo, error) {
+	post := models.PostInfo{}
+	result := store.db.Where("id = ?", postID).First(&post)
+	if result.Error != nil {
+		return models.PostInfo{}, result.Error
+	}
+	return post, nil
+}
+
+func (store *PostStore) GetPostInfo(postID uint64) (models.PostInfo, int64, int64, int64, error) {
 	post := models.PostInfo{}
 	result := store.db.Where("id = ?", postID).First(&post)
 	if result.Error != nil {
-		return models.PostInfo{}, 0, 0, result.Error
+		return models.PostInfo{}, 0, 0, 0, result.Error
 	}
 
 	postLikeCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_LIKE_COLLECTION)
 	likeCount, err := postLikeCollection.CountDocuments(context.Background(), bson.D{{Key: "post_id", Value: postID}})
 	if err != nil {
-		return models.PostInfo{}, 0, 0, err
+		return models.PostInfo{}, 0, 0, 0, err
 	}
 	postFavouriteCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_FAVORITE_COLLECTION)
 	favouriteCount, err := postFavouriteCollection.CountDocuments(context.Background(), bson.D{{Key: "post_id", Value: postID}})
 	if err != nil {
-		return models.PostInfo{}, 0, 0, err
+		return models.PostInfo{}, 0, 0, 0, err
+	}
+	postForwardCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_FORWARD_COLLECTION)
+	forwardCount, err := postForwardCollection.CountDocuments(context.Background(), bson.D{{Key: "post_id", Value: postID}})
+	if err != nil {
+		return models.PostInfo{}, 0, 0, 0, err
 	}
 
-	return post, likeCount, favouriteCount, nil
+	return post, likeCount, favouriteCount, forwardCount, nil
 }
 
-func (store *PostStore) CreatePost(uid uint64, ipAddr string, postReqData types.PostCreateBody) (models.PostInfo, error) {
+func (store *PostStore) CreatePost(uid uint64, ipAddr string, parentPostID *uint64, postReqData types.PostCreateBody) (models.PostInfo, error) {
 	var imageFileNames []string
 
 	for _, imageUUID := range postReqData.Images {
@@ -144,7 +223,7 @@ func (store *PostStore) CreatePost(uid uint64, ipAddr string, postReqData types.
 	}
 
 	postInfo := models.PostInfo{
-		ParentPostID: nil,
+		ParentPostID: parentPostID,
 		UID:          uid,
 		IpAddrress:   &ipAddr,
 		Title:        postReqData.Title,
@@ -155,8 +234,55 @@ func (store *PostStore) CreatePost(uid uint64, ipAddr string, postReqData types.
 		Farward:      pq.Int64Array{},
 		IsPublic:     true,
 	}
-	result := store.db.Create(&postInfo)
-	return postInfo, result.Error
+	err := store.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Create(&postInfo); result.Error != nil {
+			return result.Error
+		}
+		if err := createOutboxEvents(tx, types.OUTBOX_EVENT_POST_INDEX_CREATE, uint64(postInfo.ID)); err != nil {
+			return err
+		}
+		if err := createOutboxEvents(tx, types.OUTBOX_EVENT_POST_TIMELINE_FANOUT, uint64(postInfo.ID)); err != nil {
+			return err
+		}
+		if parentPostID == nil {
+			return nil
+		}
+
+		result := tx.Model(&models.PostInfo{}).
+			Where("id = ?", *parentPostID).
+			Where("farward IS NULL OR NOT (? = ANY(farward))", uid).
+			UpdateColumn("farward", gorm.Expr("array_append(COALESCE(farward, '{}'), ?)", uid))
+		if result.Error != nil {
+			return result.Error
+		}
+		return createOutboxEvents(tx, types.OUTBOX_EVENT_POST_QUOTE_CREATE, uint64(postInfo.ID))
+	})
+	if err != nil {
+		return models.PostInfo{}, err
+	}
+
+	return postInfo, nil
+}
+
+func (store *PostStore) SaveQuoteForward(post models.PostInfo) error {
+	if post.ParentPostID == nil {
+		return nil
+	}
+
+	filter := bson.D{
+		{Key: "uid", Value: int64(post.UID)},
+		{Key: "post_id", Value: int64(*post.ParentPostID)},
+		{Key: "quote_post_id", Value: int64(post.ID)},
+	}
+	update := bson.D{
+		{Key: "$setOnInsert", Value: bson.D{
+			{Key: "forwarded_at", Value: post.CreatedAt},
+		}},
+	}
+
+	postForwardCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_FORWARD_COLLECTION)
+	_, err := postForwardCollection.UpdateOne(context.Background(), filter, update, options.Update().SetUpsert(true))
+	return err
 }
 
 func (store *PostStore) CachePostImage(image []byte) (string, error) {
@@ -338,7 +464,59 @@ func (store *PostStore) CancelFavouritePost(uid, postID int64) error {
 	return err
 }
 
-func (store *PostStore) GetPostUserStatus(uid, postID int64) (bool, bool, error) {
+func (store *PostStore) RepostPost(uid, postID int64) error {
+	filter := bson.D{
+		{Key: "uid", Value: uid},
+		{Key: "post_id", Value: postID},
+		{Key: "quote_post_id", Value: nil},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "forwarded_at", Value: time.Now()},
+		}},
+	}
+
+	postForwardCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_FORWARD_COLLECTION)
+	_, err := postForwardCollection.UpdateOne(context.Background(), filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return errors.New("user has reposted this post")
+	}
+	if err != nil {
+		return err
+	}
+
+	result := store.db.Model(&models.PostInfo{}).
+		Where("id = ?", postID).
+		Where("farward IS NULL OR NOT (? = ANY(farward))", uid).
+		UpdateColumn("farward", gorm.Expr("array_append(COALESCE(farward, '{}'), ?)", uid))
+	return result.Error
+}
+
+func (store *PostStore) CancelRepostPost(uid, postID int64) error {
+	filter := bson.D{
+		{Key: "uid", Value: uid},
+		{Key: "post_id", Value: postID},
+		{Key: "quote_post_id", Value: nil},
+	}
+
+	postForwardCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_FORWARD_COLLECTION)
+	result, err := postForwardCollection.DeleteOne(context.Background(), filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("user has not reposted this post")
+	}
+
+	remainingQuotes := store.db.Model(&models.PostInfo{}).
+		Select("1").
+		Where("parent_post_id = ? AND uid = ?", postID, uid)
+	return store.db.Model(&models.PostInfo{}).
+		Where("id = ? AND NOT EXISTS (?)", postID, remainingQuotes).
+		UpdateColumn("farward", gorm.Expr("array_remove(farward, ?)", uid)).Error
+}
+
+func (store *PostStore) GetPostUserStatus(uid, postID int64) (bool, bool, bool, error) {
 
 	filter := bson.D{
 		{Key: "uid", Value: uid},
@@ -348,20 +526,89 @@ func (store *PostStore) GetPostUserStatus(uid, postID int64) (bool, bool, error)
 	postLikeCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_LIKE_COLLECTION)
 	count, err := postLikeCollection.CountDocuments(context.Background(), filter)
 	if err != nil {
-		return false, false, err
+		return false, false, false, err
 	}
 	isLiked := count > 0
 
 	postFavouriteCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_FAVORITE_COLLECTION)
 	count, err = postFavouriteCollection.CountDocuments(context.Background(), filter)
 	if err != nil {
-		return false, false, err
+		return false, false, false, err
 	}
 	isFavourited := count > 0
 
-	return isLiked, isFavourited, nil
+	postForwardCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_FORWARD_COLLECTION)
+	count, err = postForwardCollection.CountDocuments(context.Background(), append(filter, bson.E{Key: "quote_post_id", Value: nil}))
+	if err != nil {
+		return false, false, false, err
+	}
+	isReposted := count > 0
+
+	return isLiked, isFavourited, isReposted, nil
+}
+
+func (store *PostStore) UpdatePost(postID uint64, title, content string) error {
+	postInfo := new(models.PostInfo)
+	result := store.db.Where("id = ?", postID).First(postInfo)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	postInfo.Title = title
+	postInfo.Content = content
+	err := store.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Save(postInfo); result.Error != nil {
+			return result.Error
+		}
+		return createOutboxEvents(tx, types.OUTBOX_EVENT_POST_INDEX_UPDATE, postID)
+	})
+	return err
 }
 
 func (store *PostStore) DeletePost(postID uint64) error {
-	return store.db.Where("id = ?", postID).Unscoped().Delete(&models.PostInfo{}).Error
+	err := store.db.Transaction(func(tx *gorm.DB) error {
+		var post models.PostInfo
+		if result := tx.Select("id", "uid", "parent_post_id").Where("id = ?", postID).First(&post); result.Error != nil {
+			return result.Error
+		}
+		if result := tx.Where("id = ?", postID).Unscoped().Delete(&models.PostInfo{}); result.Error != nil {
+			return result.Error
+		}
+
+		if post.ParentPostID != nil {
+			plainReposts, err := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_FORWARD_COLLECTION).CountDocuments(context.Background(), bson.D{
+				{Key: "uid", Value: int64(post.UID)},
+				{Key: "post_id", Value: int64(*post.ParentPostID)},
+				{Key: "quote_post_id", Value: nil},
+			})
+			if err != nil {
+				return err
+			}
+			if plainReposts == 0 {
+				remainingQuotes := tx.Model(&models.PostInfo{}).
+					Select("1").
+					Where("parent_post_id = ? AND uid = ?", *post.ParentPostID, post.UID)
+				result := tx.Model(&models.PostInfo{}).
+					Where("id = ? AND NOT EXISTS (?)", *post.ParentPostID, remainingQuotes).
+					UpdateColumn("farward", gorm.Expr("array_remove(farward, ?)", post.UID))
+				if result.Error != nil {
+					return result.Error
+				}
+			}
+		}
+
+		return createOutboxEvents(tx, types.OUTBOX_EVENT_POST_INDEX_DELETE, postID)
+	})
+	if err != nil {
+		return err
+	}
+
+	postForwardCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_FORWARD_COLLECTION)
+	_, err = postForwardCollection.DeleteMany(context.Background(), bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "quote_post_id", Value: int64(postID)}},
+			bson.D{{Key: "post_id", Value: int64(postID)}, {Key: "quote_post_id", Value: nil}},
+		}},
+	})
+	return err
 }
@@ -0,0 +1,53 @@
+package stores
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+if count < limit then
+	redis.call("ZADD", KEYS[1], now, ARGV[4])
+	redis.call("PEXPIRE", KEYS[1], window)
+	return {1, limit - count - 1, 0}
+end
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+local retryAfter = window
+if oldest[2] then
+	retryAfter = tonumber(oldest[2]) + window - now
+end
+return {0, 0, retryAfter}
+`)
+
+type RateLimitStore struct {
+	rds *redis.Client
+}
+
+func (factory *Factory) NewRateLimitStore() *RateLimitStore {
+	return &RateLimitStore{factory.rds}
+}
+
+func (store *RateLimitStore) Allow(key string, limit int, window time.Duration) (bool, int, time.Duration, error) {
+	result, err := slidingWindowScript.Run(
+		context.Background(),
+		store.rds,
+		[]string{key},
+		time.Now().UnixMilli(),
+		window.Milliseconds(),
+		limit,
+		uuid.New().String(),
+	).Int64Slice()
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	return result[0] == 1, int(result[1]), time.Duration(result[2]) * time.Millisecond, nil
+}
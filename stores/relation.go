@@ -0,0 +1,171 @@
+package stores
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+)
+
+type RelationStore struct {
+	mongo *mongo.Client
+}
+
+func (factory *Factory) NewRelationStore() *RelationStore {
+	return &RelationStore{
+		mongo: factory.mongo,
+	}
+}
+
+func (store *RelationStore) collection(name string) *mongo.Collection {
+	return store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(name)
+}
+
+func (store *RelationStore) createRelation(collectionName string, uid, targetID uint64) error {
+
+	filter := bson.D{
+		{Key: "uid", Value: uid},
+		{Key: "target_id", Value: targetID},
+	}
+
+	update := bson.D{
+		{Key: "$setOnInsert", Value: bson.D{
+			{Key: "created_at", Value: time.Now()},
+		}},
+	}
+
+	_, err := store.collection(collectionName).UpdateOne(context.Background(), filter, update, options.Update().SetUpsert(true))
+
+	return err
+}
+
+func (store *RelationStore) deleteRelation(collectionName string, uid, targetID uint64) error {
+
+	filter := bson.D{
+		{Key: "uid", Value: uid},
+		{Key: "target_id", Value: targetID},
+	}
+
+	_, err := store.collection(collectionName).DeleteOne(context.Background(), filter)
+
+	return err
+}
+
+func (store *RelationStore) getRelationList(collectionName string, uid uint64) ([]models.RelationInfo, error) {
+	var relations []models.RelationInfo
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	err := findAll(store.collection(collectionName), bson.M{"uid": uid}, &relations, opts)
+	if err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+func (store *RelationStore) BlockUser(uid, targetID uint64) error {
+	return store.createRelation(consts.BLOCK_RECORD_COLLECTION, uid, targetID)
+}
+
+func (store *RelationStore) UnblockUser(uid, targetID uint64) error {
+	return store.deleteRelation(consts.BLOCK_RECORD_COLLECTION, uid, targetID)
+}
+
+func (store *RelationStore) MuteUser(uid, targetID uint64) error {
+	return store.createRelation(consts.MUTE_RECORD_COLLECTION, uid, targetID)
+}
+
+func (store *RelationStore) UnmuteUser(uid, targetID uint64) error {
+	return store.deleteRelation(consts.MUTE_RECORD_COLLECTION, uid, targetID)
+}
+
+func (store *RelationStore) GetBlockList(uid uint64) ([]models.RelationInfo, error) {
+	return store.getRelationList(consts.BLOCK_RECORD_COLLECTION, uid)
+}
+
+func (store *RelationStore) GetMuteList(uid uint64) ([]models.RelationInfo, error) {
+	return store.getRelationList(consts.MUTE_RECORD_COLLECTION, uid)
+}
+
+func (store *RelationStore) IsBlockedBetween(uid, targetID uint64) (bool, error) {
+	return isBlockedBetween(store.mongo, uid, targetID)
+}
+
+func (store *RelationStore) IsMuted(uid, targetID uint64) (bool, error) {
+	return isMuted(store.mongo, uid, targetID)
+}
+
+func (store *RelationStore) GetMuterUIDs(targetID uint64) (map[uint64]struct{}, error) {
+	var mutes []models.RelationInfo
+	err := findAll(store.collection(consts.MUTE_RECORD_COLLECTION), bson.M{"target_id": targetID}, &mutes)
+	if err != nil {
+		return nil, err
+	}
+
+	muterUIDs := make(map[uint64]struct{}, len(mutes))
+	for _, mute := range mutes {
+		muterUIDs[mute.UserID] = struct{}{}
+	}
+	return muterUIDs, nil
+}
+
+func isBlockedBetween(mongoClient *mongo.Client, uid, targetID uint64) (bool, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"uid": uid, "target_id": targetID},
+		bson.M{"uid": targetID, "target_id": uid},
+	}}
+	count, err := mongoClient.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.BLOCK_RECORD_COLLECTION).CountDocuments(context.Background(), filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func isMuted(mongoClient *mongo.Client, uid, targetID uint64) (bool, error) {
+	filter := bson.M{"uid": uid, "target_id": targetID}
+	count, err := mongoClient.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.MUTE_RECORD_COLLECTION).CountDocuments(context.Background(), filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (store *RelationStore) GetHiddenUIDs(uid uint64, includeMuted bool) (map[uint64]struct{}, error) {
+	hiddenUIDs := make(map[uint64]struct{})
+	if uid == 0 {
+		return hiddenUIDs, nil
+	}
+
+	var blocks []models.RelationInfo
+	err := findAll(store.collection(consts.BLOCK_RECORD_COLLECTION), bson.M{"$or": bson.A{
+		bson.M{"uid": uid},
+		bson.M{"target_id": uid},
+	}}, &blocks)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range blocks {
+		if block.UserID == uid {
+			hiddenUIDs[block.TargetID] = struct{}{}
+		} else {
+			hiddenUIDs[block.UserID] = struct{}{}
+		}
+	}
+
+	if !includeMuted {
+		return hiddenUIDs, nil
+	}
+
+	mutes, err := store.GetMuteList(uid)
+	if err != nil {
+		return nil, err
+	}
+	for _, mute := range mutes {
+		hiddenUIDs[mute.TargetID] = struct{}{}
+	}
+
+	return hiddenUIDs, nil
+}
@@ -16,7 +16,7 @@ func (factory *Factory) NewReplyStore() *ReplyStore {
 	return &ReplyStore{factory.db}
 }
 
-func (store *ReplyStore) CreateReply(uid, commentID uint64, parentReplyID, parentReplyUID *uint64, content string) error {
+func (store *ReplyStore) CreateReply(uid, commentID uint64, parentReplyID, parentReplyUID *uint64, content string) (uint64, error) {
 	newReply := &models.ReplyInfo{
 		CommentID:      commentID,
 		ParentReplyID:  parentReplyID,
@@ -30,10 +30,10 @@ func (store *ReplyStore) CreateReply(uid, commentID uint64, parentReplyID, paren
 
 	result := store.db.Create(newReply)
 	if result.Error != nil {
-		return result.Error
+		return 0, result.Error
 	}
 
-	return nil
+	return uint64(newReply.ID), nil
 }
 
 func (store *ReplyStore) ValidateReplyExistence(commentID, parentReplyID uint64) (bool, error) {
@@ -52,16 +52,16 @@ func (store *ReplyStore) ValidateReplyExistence(commentID, parentReplyID uint64)
 	return true, nil
 }
 
-func (store *ReplyStore) DeleteReply(uid, replyID uint64) error {
-	result := store.db.Model(&models.ReplyInfo{}).Where("id = ? AND uid = ?", replyID, uid).Unscoped().Delete(&models.ReplyInfo{})
+func (store *ReplyStore) DeleteReply(replyID uint64) error {
+	result := store.db.Model(&models.ReplyInfo{}).Where("id = ?", replyID).Unscoped().Delete(&models.ReplyInfo{})
 	if result.Error != nil {
 		return result.Error
 	}
 	return nil
 }
 
-func (store *ReplyStore) UpdateReply(uid, replyID uint64, content string) error {
-	result := store.db.Model(&models.ReplyInfo{}).Where("id = ? AND uid = ?", replyID, uid).Update("content", content)
+func (store *ReplyStore) UpdateReply(replyID uint64, content string) error {
+	result := store.db.Model(&models.ReplyInfo{}).Where("id = ?", replyID).Update("content", content)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -0,0 +1,100 @@
+package stores
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+var searchRebuildLockRefreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+redis.call("EXPIRE", KEYS[1], ARGV[2])
+return 1
+`)
+
+var searchRebuildLockReleaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+return redis.call("DEL", KEYS[1])
+`)
+
+type SearchIndexStore struct {
+	rds *redis.Client
+}
+
+func (factory *Factory) NewSearchIndexStore() *SearchIndexStore {
+	return &SearchIndexStore{factory.rds}
+}
+
+func (store *SearchIndexStore) GetRebuildProgress() (*types.SearchRebuildProgress, error) {
+	ctx := context.Background()
+
+	result := store.rds.HGetAll(ctx, consts.REDIS_SEARCH_REBUILD_PROGRESS)
+	values, err := result.Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return &types.SearchRebuildProgress{Status: types.SEARCH_REBUILD_STATUS_IDLE}, nil
+	}
+
+	progress := new(types.SearchRebuildProgress)
+	err = result.Scan(progress)
+	if err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+func (store *SearchIndexStore) SaveRebuildProgress(progress *types.SearchRebuildProgress) error {
+	progress.UpdatedAt = time.Now().Unix()
+	return store.rds.HSet(context.Background(), consts.REDIS_SEARCH_REBUILD_PROGRESS, *progress).Err()
+}
+
+func (store *SearchIndexStore) AcquireRebuildLock(token string) (bool, error) {
+	return store.rds.SetNX(
+		context.Background(),
+		consts.REDIS_SEARCH_REBUILD_LOCK,
+		token,
+		consts.SEARCH_REBUILD_LOCK_DURATION*time.Second,
+	).Result()
+}
+
+func (store *SearchIndexStore) RefreshRebuildLock(token string) (bool, error) {
+	refreshed, err := searchRebuildLockRefreshScript.Run(
+		context.Background(),
+		store.rds,
+		[]string{consts.REDIS_SEARCH_REBUILD_LOCK},
+		token,
+		consts.SEARCH_REBUILD_LOCK_DURATION,
+	).Int()
+	if err != nil {
+		return false, err
+	}
+	return refreshed == 1, nil
+}
+
+func (store *SearchIndexStore) ReleaseRebuildLock(token string) error {
+	return searchRebuildLockReleaseScript.Run(
+		context.Background(),
+		store.rds,
+		[]string{consts.REDIS_SEARCH_REBUILD_LOCK},
+		token,
+	).Err()
+}
+
+func (store *SearchIndexStore) IsRebuildLocked() (bool, error) {
+	count, err := store.rds.Exists(context.Background(), consts.REDIS_SEARCH_REBUILD_LOCK).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,156 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+var sessionRotateScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "refresh_hash") ~= ARGV[1] then
+	return 0
+end
+redis.call("HSET", KEYS[1], "refresh_hash", ARGV[2], "previous_hash", ARGV[1], "access_token_id", ARGV[3], "refreshed_at", ARGV[4])
+redis.call("EXPIRE", KEYS[1], ARGV[5])
+return 1
+`)
+
+type SessionStore struct {
+	rds *redis.Client
+}
+
+func (factory *Factory) NewSessionStore() *SessionStore {
+	return &SessionStore{factory.rds}
+}
+
+func (store *SessionStore) sessionKey(uid uint64, sessionID string) string {
+	var sb strings.Builder
+	sb.WriteString(consts.REDIS_USER_SESSION)
+	sb.WriteRune(':')
+	sb.WriteString(strconv.FormatUint(uid, 10))
+	sb.WriteRune(':')
+	sb.WriteString(sessionID)
+	return sb.String()
+}
+
+func (store *SessionStore) sessionIndexKey(uid uint64) string {
+	var sb strings.Builder
+	sb.WriteString(consts.REDIS_USER_SESSION_INDEX)
+	sb.WriteRune(':')
+	sb.WriteString(strconv.FormatUint(uid, 10))
+	return sb.String()
+}
+
+func (store *SessionStore) CreateSession(uid uint64, sessionID, refreshHash, accessTokenID string) error {
+	ctx := context.Background()
+	now := time.Now()
+	key := store.sessionKey(uid, sessionID)
+	indexKey := store.sessionIndexKey(uid)
+
+	tx := store.rds.TxPipeline()
+	tx.HSet(ctx, key, types.UserSession{
+		SessionID:     sessionID,
+		RefreshHash:   refreshHash,
+		AccessTokenID: accessTokenID,
+		CreatedAt:     now.Unix(),
+		RefreshedAt:   now.Unix(),
+	})
+	tx.Expire(ctx, key, consts.REFRESH_TOKEN_EXPIRE_DURATION*time.Second)
+	tx.ZAdd(ctx, indexKey, redis.Z{Score: float64(now.Unix()), Member: sessionID})
+	tx.Expire(ctx, indexKey, consts.REFRESH_TOKEN_EXPIRE_DURATION*time.Second)
+
+	_, err := tx.Exec(ctx)
+	return err
+}
+
+func (store *SessionStore) GetSession(uid uint64, sessionID string) (*types.UserSession, error) {
+	ctx := context.Background()
+
+	result := store.rds.HGetAll(ctx, store.sessionKey(uid, sessionID))
+	values, err := result.Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, redis.Nil
+	}
+
+	session := new(types.UserSession)
+	err = result.Scan(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (store *SessionStore) RotateSession(uid uint64, sessionID, oldRefreshHash, newRefreshHash, accessTokenID string) (bool, error) {
+	ctx := context.Background()
+
+	rotated, err := sessionRotateScript.Run(
+		ctx,
+		store.rds,
+		[]string{store.sessionKey(uid, sessionID)},
+		oldRefreshHash,
+		newRefreshHash,
+		accessTokenID,
+		time.Now().Unix(),
+		consts.REFRESH_TOKEN_EXPIRE_DURATION,
+	).Int()
+	if err != nil {
+		return false, err
+	}
+
+	indexKey := store.sessionIndexKey(uid)
+	err = store.rds.Expire(ctx, indexKey, consts.REFRESH_TOKEN_EXPIRE_DURATION*time.Second).Err()
+	if err != nil {
+		return false, err
+	}
+
+	return rotated == 1, nil
+}
+
+func (store *SessionStore) DeleteSession(uid uint64, sessionID string) error {
+	ctx := context.Background()
+
+	tx := store.rds.TxPipeline()
+	tx.Del(ctx, store.sessionKey(uid, sessionID))
+	tx.ZRem(ctx, store.sessionIndexKey(uid), sessionID)
+
+	_, err := tx.Exec(ctx)
+	return err
+}
+
+func (store *SessionStore) GetSessionList(uid uint64) ([]types.UserSession, error) {
+	ctx := context.Background()
+
+	sessionIDs, err := store.rds.ZRevRange(ctx, store.sessionIndexKey(uid), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []types.UserSession
+	for _, sessionID := range sessionIDs {
+		session, err := store.GetSession(uid, sessionID)
+		if errors.Is(err, redis.Nil) {
+			err = store.rds.ZRem(ctx, store.sessionIndexKey(uid), sessionID).Err()
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+
+	return sessions, nil
+}
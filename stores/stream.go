@@ -0,0 +1,73 @@
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type StreamStore struct {
+	rds *redis.Client
+}
+
+func (factory *Factory) NewStreamStore() *StreamStore {
+	return &StreamStore{
+		rds: factory.rds,
+	}
+}
+
+func userStreamChannel(uid uint64) string {
+	var sb strings.Builder
+	sb.WriteString(consts.REDIS_USER_STREAM_CHANNEL)
+	sb.WriteRune(':')
+	sb.WriteString(strconv.FormatUint(uid, 10))
+	return sb.String()
+}
+
+func postStreamChannel(postID uint64) string {
+	var sb strings.Builder
+	sb.WriteString(consts.REDIS_POST_STREAM_CHANNEL)
+	sb.WriteRune(':')
+	sb.WriteString(strconv.FormatUint(postID, 10))
+	return sb.String()
+}
+
+func publishStreamEvent(rds redis.Cmdable, channel string, event types.StreamEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return rds.Publish(context.Background(), channel, payload).Err()
+}
+
+func (store *StreamStore) PublishUserEvent(uid uint64, event types.StreamEvent) error {
+	return publishStreamEvent(store.rds, userStreamChannel(uid), event)
+}
+
+func (store *StreamStore) PublishPostEvent(postID uint64, event types.StreamEvent) error {
+	return publishStreamEvent(store.rds, postStreamChannel(postID), event)
+}
+
+func (store *StreamStore) Subscribe(uid uint64, postIDs []uint64) (*redis.PubSub, error) {
+	channels := make([]string, 0, len(postIDs)+1)
+	channels = append(channels, userStreamChannel(uid))
+	for _, postID := range postIDs {
+		channels = append(channels, postStreamChannel(postID))
+	}
+
+	ctx := context.Background()
+	pubsub := store.rds.Subscribe(ctx, channels...)
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	return pubsub, nil
+}
@@ -0,0 +1,354 @@
+package stores
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+var timelinePushScript = redis.NewScript(`
+for _, key in ipairs(KEYS) do
+	if redis.call('EXISTS', key) == 1 then
+		redis.call('ZADD', key, ARGV[1], ARGV[1])
+		redis.call('ZREMRANGEBYRANK', key, 1, -(tonumber(ARGV[2]) + 1))
+	end
+end
+return 0
+`)
+
+var timelineMergeScript = redis.NewScript(`
+for _, key in ipairs(KEYS) do
+	if redis.call('EXISTS', key) == 1 then
+		for index = 2, #ARGV do
+			redis.call('ZADD', key, ARGV[index], ARGV[index])
+		end
+		redis.call('ZREMRANGEBYRANK', key, 1, -(tonumber(ARGV[1]) + 1))
+	end
+end
+return 0
+`)
+
+type TimelineStore struct {
+	db  *gorm.DB
+	rds *redis.Client
+}
+
+func (factory *Factory) NewTimelineStore() *TimelineStore {
+	return &TimelineStore{
+		db:  factory.db,
+		rds: factory.rds,
+	}
+}
+
+func timelineKey(uid uint64) string {
+	var sb strings.Builder
+	sb.WriteString(consts.REDIS_USER_TIMELINE)
+	sb.WriteRune(':')
+	sb.WriteString(strconv.FormatUint(uid, 10))
+	return sb.String()
+}
+
+func (store *TimelineStore) SetCelebrity(uid uint64, isCelebrity bool) error {
+	ctx := context.Background()
+	if isCelebrity {
+		return store.rds.SAdd(ctx, consts.REDIS_TIMELINE_CELEBRITY_SET, uid).Err()
+	}
+	return store.rds.SRem(ctx, consts.REDIS_TIMELINE_CELEBRITY_SET, uid).Err()
+}
+
+func (store *TimelineStore) IsCelebrity(uid uint64) (bool, error) {
+	return store.rds.SIsMember(context.Background(), consts.REDIS_TIMELINE_CELEBRITY_SET, uid).Result()
+}
+
+func (store *TimelineStore) DemoteCelebrity(uid uint64, followerIDs []uint64) error {
+	ctx := context.Background()
+
+	var postIDs []uint64
+	result := store.db.Model(&models.PostInfo{}).
+		Where("uid = ?", uid).
+		Order("id desc").
+		Limit(consts.TIMELINE_MAX_LENGTH).
+		Pluck("id", &postIDs)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if len(postIDs) > 0 {
+		args := make([]interface{}, 0, len(postIDs)+1)
+		args = append(args, consts.TIMELINE_MAX_LENGTH)
+		for _, postID := range postIDs {
+			args = append(args, postID)
+		}
+
+		for start := 0; start < len(followerIDs); start += consts.TIMELINE_FANOUT_BATCH_SIZE {
+			end := min(start+consts.TIMELINE_FANOUT_BATCH_SIZE, len(followerIDs))
+
+			keys := make([]string, 0, end-start)
+			for _, followerID := range followerIDs[start:end] {
+				keys = append(keys, timelineKey(followerID))
+			}
+
+			err := timelineMergeScript.Run(ctx, store.rds, keys, args...).Err()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return store.SetCelebrity(uid, false)
+}
+
+func (store *TimelineStore) PushPost(postID uint64, followerIDs []uint64, silentIDs map[uint64]struct{}) error {
+	ctx := context.Background()
+
+	for start := 0; start < len(followerIDs); start += consts.TIMELINE_FANOUT_BATCH_SIZE {
+		end := start + consts.TIMELINE_FANOUT_BATCH_SIZE
+		if end > len(followerIDs) {
+			end = len(followerIDs)
+		}
+
+		keys := make([]string, 0, end-start)
+		for _, followerID := range followerIDs[start:end] {
+			keys = append(keys, timelineKey(followerID))
+		}
+
+		err := timelinePushScript.Run(ctx, store.rds, keys, postID, consts.TIMELINE_MAX_LENGTH).Err()
+		if err != nil {
+			return err
+		}
+
+		pipe := store.rds.Pipeline()
+		for _, followerID := range followerIDs[start:end] {
+			if _, silent := silentIDs[followerID]; silent {
+				continue
+			}
+			err = publishStreamEvent(pipe, userStreamChannel(followerID), types.StreamEvent{
+				Type: types.STREAM_EVENT_TIMELINE_POST,
+				Data: types.TimelinePostStreamData{PostID: postID},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		_, err = pipe.Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (store *TimelineStore) RemovePost(postID uint64, followerIDs []uint64) error {
+	ctx := context.Background()
+
+	pipe := store.rds.Pipeline()
+	for _, followerID := range followerIDs {
+		pipe.ZRem(ctx, timelineKey(followerID), postID)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (store *TimelineStore) AddUserPosts(uid, followedID uint64) error {
+	ctx := context.Background()
+	key := timelineKey(uid)
+
+	exists, err := store.rds.Exists(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		return nil
+	}
+
+	var postIDs []uint64
+	result := store.db.Model(&models.PostInfo{}).
+		Where("uid = ?", followedID).
+		Order("id desc").
+		Limit(consts.TIMELINE_MAX_LENGTH).
+		Pluck("id", &postIDs)
+	if result.Error != nil {
+		return result.Error
+	}
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	members := make([]redis.Z, len(postIDs))
+	for index, postID := range postIDs {
+		members[index] = redis.Z{Score: float64(postID), Member: postID}
+	}
+
+	tx := store.rds.TxPipeline()
+	tx.ZAdd(ctx, key, members...)
+	tx.ZRemRangeByRank(ctx, key, 1, -(consts.TIMELINE_MAX_LENGTH + 1))
+	_, err = tx.Exec(ctx)
+	return err
+}
+
+func (store *TimelineStore) RemoveUserPosts(uid, followedID uint64) error {
+	ctx := context.Background()
+	key := timelineKey(uid)
+
+	exists, err := store.rds.Exists(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		return nil
+	}
+
+	var postIDs []uint64
+	result := store.db.Model(&models.PostInfo{}).
+		Where("uid = ?", followedID).
+		Order("id desc").
+		Limit(consts.TIMELINE_MAX_LENGTH).
+		Pluck("id", &postIDs)
+	if result.Error != nil {
+		return result.Error
+	}
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, len(postIDs))
+	for index, postID := range postIDs {
+		members[index] = postID
+	}
+
+	return store.rds.ZRem(ctx, key, members...).Err()
+}
+
+func (store *TimelineStore) GetTimeline(uid uint64, followedIDs []uint64, from string, length int) ([]int64, error) {
+	ctx := context.Background()
+	key := timelineKey(uid)
+
+	var (
+		celebrityIDs []uint64
+		ordinaryIDs  []uint64
+	)
+	if len(followedIDs) > 0 {
+		members := make([]interface{}, len(followedIDs))
+		for index, followedID := range followedIDs {
+			members[index] = followedID
+		}
+		isCelebrity, err := store.rds.SMIsMember(ctx, consts.REDIS_TIMELINE_CELEBRITY_SET, members...).Result()
+		if err != nil {
+			return nil, err
+		}
+		for index, followedID := range followedIDs {
+			if isCelebrity[index] {
+				celebrityIDs = append(celebrityIDs, followedID)
+			} else {
+				ordinaryIDs = append(ordinaryIDs, followedID)
+			}
+		}
+	}
+
+	exists, err := store.rds.Exists(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if exists == 0 {
+		err = store.buildTimeline(key, ordinaryIDs)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		store.rds.Expire(ctx, key, consts.TIMELINE_EXPIRE_TIME*time.Second)
+	}
+
+	maxScore := "+inf"
+	if from != "" {
+		maxScore = "(" + from
+	}
+	members, err := store.rds.ZRevRangeByScore(ctx, key, &redis.ZRangeBy{
+		Max:   maxScore,
+		Min:   "(0",
+		Count: int64(length),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	postIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		postID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	if len(celebrityIDs) == 0 {
+		return postIDs, nil
+	}
+
+	var celebrityPostIDs []int64
+	query := store.db.Model(&models.PostInfo{}).Where("uid IN ?", celebrityIDs)
+	if from != "" {
+		query = query.Where("id < ?", from)
+	}
+	result := query.Order("id desc").Limit(length).Pluck("id", &celebrityPostIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	seen := make(map[int64]bool, len(postIDs)+len(celebrityPostIDs))
+	merged := make([]int64, 0, len(postIDs)+len(celebrityPostIDs))
+	for _, postID := range append(postIDs, celebrityPostIDs...) {
+		if seen[postID] {
+			continue
+		}
+		seen[postID] = true
+		merged = append(merged, postID)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i] > merged[j]
+	})
+	if len(merged) > length {
+		merged = merged[:length]
+	}
+
+	return merged, nil
+}
+
+func (store *TimelineStore) buildTimeline(key string, followedIDs []uint64) error {
+	ctx := context.Background()
+
+	var postIDs []uint64
+	if len(followedIDs) > 0 {
+		result := store.db.Model(&models.PostInfo{}).
+			Where("uid IN ?", followedIDs).
+			Order("id desc").
+			Limit(consts.TIMELINE_MAX_LENGTH).
+			Pluck("id", &postIDs)
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+
+	members := make([]redis.Z, 0, len(postIDs)+1)
+	members = append(members, redis.Z{Score: 0, Member: 0})
+	for _, postID := range postIDs {
+		members = append(members, redis.Z{Score: float64(postID), Member: postID})
+	}
+
+	tx := store.rds.TxPipeline()
+	tx.Del(ctx, key)
+	tx.ZAdd(ctx, key, members...)
+	tx.Expire(ctx, key, consts.TIMELINE_EXPIRE_TIME*time.Second)
+	_, err := tx.Exec(ctx)
+	return err
+}
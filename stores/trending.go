@@ -0,0 +1,44 @@
+package stores
+
+import (
+	"context"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+)
+
+type TrendingStore struct {
+	rds *redis.Client
+}
+
+func (factory *Factory) NewTrendingStore() *TrendingStore {
+	return &TrendingStore{factory.rds}
+}
+
+func (store *TrendingStore) trendingKey(prefix, window string) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteRune(':')
+	sb.WriteString(window)
+	return sb.String()
+}
+
+func (store *TrendingStore) GetTrendingPosts(window string, length int) ([]redis.Z, error) {
+	return store.rds.ZRevRangeWithScores(
+		context.Background(),
+		store.trendingKey(consts.REDIS_TRENDING_POST, window),
+		0,
+		int64(length-1),
+	).Result()
+}
+
+func (store *TrendingStore) GetTrendingTags(window string, length int) ([]redis.Z, error) {
+	return store.rds.ZRevRangeWithScores(
+		context.Background(),
+		store.trendingKey(consts.REDIS_TRENDING_TAG, window),
+		0,
+		int64(length-1),
+	).Result()
+}
@@ -0,0 +1,169 @@
+package stores
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+var totpCounterScript = redis.NewScript(`
+local last = tonumber(redis.call("GET", KEYS[1]) or "-1")
+if tonumber(ARGV[1]) <= last then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1], "EX", ARGV[2])
+return 1
+`)
+
+var loginChallengeAttemptScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return -1
+end
+return redis.call("HINCRBY", KEYS[1], "attempts", 1)
+`)
+
+type TwoFactorStore struct {
+	db  *gorm.DB
+	rds *redis.Client
+}
+
+func (factory *Factory) NewTwoFactorStore() *TwoFactorStore {
+	return &TwoFactorStore{factory.db, factory.rds}
+}
+
+func (store *TwoFactorStore) twoFactorKey(prefix, subject string) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteRune(':')
+	sb.WriteString(subject)
+	return sb.String()
+}
+
+func (store *TwoFactorStore) SetPendingSecret(uid uint64, secret string) error {
+	return store.rds.Set(
+		context.Background(),
+		store.twoFactorKey(consts.REDIS_TOTP_ENROLL, strconv.FormatUint(uid, 10)),
+		secret,
+		consts.TOTP_ENROLL_EXPIRE_TIME*time.Second,
+	).Err()
+}
+
+func (store *TwoFactorStore) GetPendingSecret(uid uint64) (string, error) {
+	return store.rds.Get(
+		context.Background(),
+		store.twoFactorKey(consts.REDIS_TOTP_ENROLL, strconv.FormatUint(uid, 10)),
+	).Result()
+}
+
+func (store *TwoFactorStore) DeletePendingSecret(uid uint64) error {
+	return store.rds.Del(
+		context.Background(),
+		store.twoFactorKey(consts.REDIS_TOTP_ENROLL, strconv.FormatUint(uid, 10)),
+	).Err()
+}
+
+func (store *TwoFactorStore) EnableTwoFactor(uid uint64, secret string, recoveryCodeHashes []string) error {
+	result := store.db.Model(&models.UserAuthInfo{}).Where("uid = ?", uid).Updates(map[string]interface{}{
+		"totp_secret":    secret,
+		"totp_enabled":   true,
+		"recovery_codes": pq.StringArray(recoveryCodeHashes),
+	})
+	return result.Error
+}
+
+func (store *TwoFactorStore) DisableTwoFactor(uid uint64) error {
+	result := store.db.Model(&models.UserAuthInfo{}).Where("uid = ?", uid).Updates(map[string]interface{}{
+		"totp_secret":    nil,
+		"totp_enabled":   false,
+		"recovery_codes": pq.StringArray{},
+	})
+	return result.Error
+}
+
+func (store *TwoFactorStore) ConsumeRecoveryCode(uid uint64, recoveryCodeHash string) (bool, error) {
+	result := store.db.Model(&models.UserAuthInfo{}).
+		Where("uid = ? AND ? = ANY(recovery_codes)", uid, recoveryCodeHash).
+		UpdateColumn("recovery_codes", gorm.Expr("array_remove(recovery_codes, ?)", recoveryCodeHash))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (store *TwoFactorStore) UpdateRecoveryCodes(uid uint64, recoveryCodeHashes []string) error {
+	result := store.db.Model(&models.UserAuthInfo{}).Where("uid = ?", uid).Update("recovery_codes", pq.StringArray(recoveryCodeHashes))
+	return result.Error
+}
+
+func (store *TwoFactorStore) MarkCounterUsed(uid uint64, counter uint64) (bool, error) {
+	used, err := totpCounterScript.Run(
+		context.Background(),
+		store.rds,
+		[]string{store.twoFactorKey(consts.REDIS_TOTP_LAST_COUNTER, strconv.FormatUint(uid, 10))},
+		counter,
+		(2*consts.TOTP_SKEW+1)*consts.TOTP_PERIOD,
+	).Int()
+	if err != nil {
+		return false, err
+	}
+	return used == 1, nil
+}
+
+func (store *TwoFactorStore) CreateLoginChallenge(tokenHash string, challenge types.LoginChallenge) error {
+	ctx := context.Background()
+	key := store.twoFactorKey(consts.REDIS_LOGIN_CHALLENGE, tokenHash)
+
+	tx := store.rds.TxPipeline()
+	tx.HSet(ctx, key, challenge)
+	tx.Expire(ctx, key, consts.LOGIN_CHALLENGE_EXPIRE_TIME*time.Second)
+	_, err := tx.Exec(ctx)
+
+	return err
+}
+
+func (store *TwoFactorStore) GetLoginChallenge(tokenHash string) (*types.LoginChallenge, error) {
+	result := store.rds.HGetAll(context.Background(), store.twoFactorKey(consts.REDIS_LOGIN_CHALLENGE, tokenHash))
+	values, err := result.Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, redis.Nil
+	}
+
+	challenge := new(types.LoginChallenge)
+	err = result.Scan(challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+func (store *TwoFactorStore) IncrLoginChallengeAttempts(tokenHash string) (int64, error) {
+	attempts, err := loginChallengeAttemptScript.Run(
+		context.Background(),
+		store.rds,
+		[]string{store.twoFactorKey(consts.REDIS_LOGIN_CHALLENGE, tokenHash)},
+	).Int64()
+	if err != nil {
+		return 0, err
+	}
+	if attempts < 0 {
+		return 0, redis.Nil
+	}
+	return attempts, nil
+}
+
+func (store *TwoFactorStore) DeleteLoginChallenge(tokenHash string) error {
+	return store.rds.Del(context.Background(), store.twoFactorKey(consts.REDIS_LOGIN_CHALLENGE, tokenHash)).Err()
+}
@@ -3,7 +3,6 @@ package stores
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -37,7 +36,7 @@ func (factory *Factory) NewUserStore() *UserStore {
 	}
 }
 
-func (store *UserStore) RegisterUserByUsername(username string, salt string, hashedPassword string) error {
+func (store *UserStore) RegisterUserByUsername(username string, pendingEmail *string, hashedPassword string) error {
 	tx := store.db.Begin()
 
 	user := models.UserInfo{
@@ -54,8 +53,8 @@ func (store *UserStore) RegisterUserByUsername(username string, salt string, has
 	userAuthInfo := models.UserAuthInfo{
 		UID:          uint64(uid),
 		UserName:     username,
-		Salt:         salt,
 		PasswordHash: hashedPassword,
+		PendingEmail: pendingEmail,
 	}
 	result = tx.Create(&userAuthInfo)
 	if result.Error != nil {
@@ -88,6 +87,12 @@ func (store *UserStore) RegisterUserByUsername(username string, salt string, has
 		return result.Error
 	}
 
+	err := createOutboxEvents(tx, types.OUTBOX_EVENT_USER_INDEX_CREATE, uint64(uid))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	return tx.Commit().Error
 }
 
@@ -100,6 +105,15 @@ func (store *UserStore) GetUserByUID(uid uint64) (*models.UserInfo, error) {
 	return user, nil
 }
 
+func (store *UserStore) GetUsersByUIDs(uids []uint64) ([]models.UserInfo, error) {
+	var users []models.UserInfo
+	result := store.db.Where("id IN ?", uids).Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return users, nil
+}
+
 func (store *UserStore) GetUserByUsername(username string) (*models.UserInfo, error) {
 	user := new(models.UserInfo)
 	result := store.db.Where("username = ?", username).First(user)
@@ -126,96 +140,81 @@ func (store *UserStore) CreateUserLoginLog(userLoginLogInfo *models.UserLoginLog
 	return nil
 }
 
-func (store *UserStore) CreateUserAvaliableToken(token string, claims *types.BearerTokenClaims) error {
+func (store *UserStore) userTokenKey(uid uint64, tokenID string) string {
+	var sb strings.Builder
+	sb.WriteString(consts.REDIS_USER_TOKEN)
+	sb.WriteRune(':')
+	sb.WriteString(strconv.FormatUint(uid, 10))
+	sb.WriteRune(':')
+	sb.WriteString(tokenID)
+	return sb.String()
+}
+
+func (store *UserStore) userTokenIndexKey(uid uint64) string {
 	var sb strings.Builder
-	sb.WriteString(consts.REDIS_AVAILABLE_USER_TOKEN_LIST)
+	sb.WriteString(consts.REDIS_USER_TOKEN_INDEX)
 	sb.WriteRune(':')
-	sb.WriteString(strconv.FormatUint(claims.UID, 10))
-	key := sb.String()
+	sb.WriteString(strconv.FormatUint(uid, 10))
+	return sb.String()
+}
 
+func (store *UserStore) CreateUserAvaliableToken(claims *types.BearerTokenClaims) error {
 	ctx := context.Background()
+	now := time.Now()
+	indexKey := store.userTokenIndexKey(claims.UID)
 
-	length, err := store.rds.LLen(ctx, key).Result()
-	if err != nil {
-		return err
+	expiresAt := now.Add(consts.TOKEN_EXPIRE_DURATION * time.Second)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
 	}
-
-	fmt.Println(length)
-
-	tx := store.rds.TxPipeline()
-
-	if length >= consts.MAX_TOKENS_PER_USER {
-
-		_, err = tx.LTrim(ctx, key, length-4, -1).Result()
-		if err != nil {
-			tx.Discard()
-			return err
-		}
+	if !expiresAt.After(now) {
+		return nil
 	}
 
-	_, err = tx.RPush(ctx, key, token).Result()
+	tx := store.rds.TxPipeline()
+	tx.Set(ctx, store.userTokenKey(claims.UID, claims.ID), 1, expiresAt.Sub(now))
+	tx.ZRemRangeByScore(ctx, indexKey, "-inf", strconv.FormatInt(now.Unix(), 10))
+	tx.ZAdd(ctx, indexKey, redis.Z{Score: float64(expiresAt.Unix()), Member: claims.ID})
+	tx.Expire(ctx, indexKey, consts.TOKEN_EXPIRE_DURATION*time.Second)
+	_, err := tx.Exec(ctx)
 	if err != nil {
-		tx.Discard()
 		return err
 	}
 
-	_, err = tx.Exec(ctx)
+	staleTokenIDs, err := store.rds.ZRange(ctx, indexKey, 0, -(consts.MAX_TOKENS_PER_USER + 1)).Result()
 	if err != nil {
-		tx.Discard()
 		return err
 	}
+	for _, tokenID := range staleTokenIDs {
+		err = store.BanUserToken(claims.UID, tokenID)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
-func (store *UserStore) BanUserToken(uid uint64, token string) error {
-	var sb strings.Builder
-	sb.WriteString(consts.REDIS_AVAILABLE_USER_TOKEN_LIST)
-	sb.WriteRune(':')
-	sb.WriteString(strconv.FormatUint(uid, 10))
-	key := sb.String()
-
+func (store *UserStore) BanUserToken(uid uint64, tokenID string) error {
 	ctx := context.Background()
-	tx := store.rds.TxPipeline()
-
-	_, err := tx.LRem(ctx, key, 0, token).Result()
-	if err != nil {
-		tx.Discard()
-		return err
-	}
 
-	_, err = tx.Exec(ctx)
-	if err != nil {
-		tx.Discard()
-		return err
-	}
+	tx := store.rds.TxPipeline()
+	tx.Del(ctx, store.userTokenKey(uid, tokenID))
+	tx.ZRem(ctx, store.userTokenIndexKey(uid), tokenID)
+	_, err := tx.Exec(ctx)
 
-	return nil
+	return err
 }
 
-func (store *UserStore) IsUserTokenAvaliable(token string) (bool, error) {
+func (store *UserStore) IsUserTokenAvaliable(claims *types.BearerTokenClaims) (bool, error) {
 	ctx := context.Background()
 
-	keys, err := store.rds.Keys(ctx, consts.REDIS_AVAILABLE_USER_TOKEN_LIST+":*").Result()
+	count, err := store.rds.Exists(ctx, store.userTokenKey(claims.UID, claims.ID)).Result()
 	if err != nil {
 		return false, err
 	}
 
-	for _, key := range keys {
-
-		tokens, err := store.rds.LRange(ctx, key, 0, -1).Result()
-		if err != nil {
-			return false, err
-		}
-
-		for _, t := range tokens {
-			if t == token {
-				return true, nil
-			}
-		}
-	}
-
-	return false, nil
+	return count > 0, nil
 }
 
 func (store *UserStore) SaveUserAvatarByUID(uid uint64, fileName string, data []byte) error {
@@ -285,7 +284,23 @@ func (store *UserStore) UpdateUserInfoByUID(uid uint64, updatedProfile *models.U
 	userProfile.Birth = updatedProfile.Birth
 	userProfile.Gender = updatedProfile.Gender
 
-	return store.db.Save(&userProfile).Error
+	return store.db.Transaction(func(tx *gorm.DB) error {
+		if result := tx.Save(&userProfile); result.Error != nil {
+			return result.Error
+		}
+		return createOutboxEvents(tx, types.OUTBOX_EVENT_USER_INDEX_UPDATE, uid)
+	})
+}
+
+func (store *UserStore) UpdateUserPrivacyByUID(uid uint64, isPrivate bool) error {
+	result := store.db.Model(&models.UserInfo{}).Where("id = ?", uid).Update("is_private", isPrivate)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 func (store *UserStore) GetUserLikedRecord(uid int64) ([]int64, error) {
@@ -345,3 +360,96 @@ func (store *UserStore) GetUserFavoriteRecord(uid int64) ([]int64, error) {
 
 	return favorited, nil
 }
+
+func (store *UserStore) GetUserRepostRecord(uid int64) ([]int64, error) {
+	postForwardCollection := store.mongo.Database(consts.MONGODB_DATABASE_NAME).Collection(consts.POST_FORWARD_COLLECTION)
+	filter := bson.D{{Key: "uid", Value: uid}, {Key: "quote_post_id", Value: nil}}
+	sort := bson.D{{Key: "forwarded_at", Value: 1}}
+	ctx := context.Background()
+	defer ctx.Done()
+
+	cursor, err := postForwardCollection.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var postForwards []models.ForwardInfo
+	err = cursor.All(ctx, &postForwards)
+	if err != nil {
+		return nil, err
+	}
+
+	reposted := make([]int64, len(postForwards))
+	for index, postForward := range postForwards {
+		reposted[index] = postForward.PostID
+	}
+
+	return reposted, nil
+}
+
+func (store *UserStore) GetUserLoginLogBySessionID(uid uint64, sessionID string) (*models.UserLoginLog, error) {
+	userLoginLog := new(models.UserLoginLog)
+	result := store.db.Where("uid = ? AND session_id = ?", uid, sessionID).First(userLoginLog)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return userLoginLog, nil
+}
+
+func (store *UserStore) GetUserLoginLogList(uid uint64, from uint64, length int) ([]models.UserLoginLog, error) {
+	var userLoginLogs []models.UserLoginLog
+	query := store.db.Where("uid = ?", uid)
+	if from > 0 {
+		query = query.Where("id < ?", from)
+	}
+	result := query.Order("id DESC").Limit(length).Find(&userLoginLogs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return userLoginLogs, nil
+}
+
+func (store *UserStore) GetUserAuthInfoByUID(uid uint64) (*models.UserAuthInfo, error) {
+	userAuthInfo := new(models.UserAuthInfo)
+	result := store.db.Where("uid = ?", uid).First(userAuthInfo)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return userAuthInfo, nil
+}
+
+func (store *UserStore) GetUserAuthInfoByEmail(email string) (*models.UserAuthInfo, error) {
+	userAuthInfo := new(models.UserAuthInfo)
+	result := store.db.Where("email = ?", email).First(userAuthInfo)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return userAuthInfo, nil
+}
+
+func (store *UserStore) UpdatePendingEmailByUID(uid uint64, email string) error {
+	result := store.db.Model(&models.UserAuthInfo{}).Where("uid = ?", uid).Update("pending_email", email)
+	return result.Error
+}
+
+func (store *UserStore) VerifyUserEmailByUID(uid uint64, email string) (bool, error) {
+	verified := false
+	err := store.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.UserAuthInfo{}).Where("uid = ? AND pending_email = ?", uid, email).Updates(map[string]interface{}{
+			"email":          email,
+			"email_verified": true,
+			"pending_email":  nil,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		verified = true
+
+		return tx.Model(&models.UserAuthInfo{}).Where("pending_email = ? AND uid <> ?", email, uid).Update("pending_email", nil).Error
+	})
+	return verified, err
+}
@@ -0,0 +1,128 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+)
+
+type VerificationStore struct {
+	rds *redis.Client
+}
+
+func (factory *Factory) NewVerificationStore() *VerificationStore {
+	return &VerificationStore{factory.rds}
+}
+
+func (store *VerificationStore) verificationKey(prefix, subject string) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteRune(':')
+	sb.WriteString(subject)
+	return sb.String()
+}
+
+func (store *VerificationStore) CreateEmailVerification(uid uint64, email string, tokenHash string) error {
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatUint(uid, 10))
+	sb.WriteRune(':')
+	sb.WriteString(email)
+
+	return store.rds.Set(
+		context.Background(),
+		store.verificationKey(consts.REDIS_EMAIL_VERIFY_TOKEN, tokenHash),
+		sb.String(),
+		consts.EMAIL_VERIFY_TOKEN_EXPIRE_TIME*time.Second,
+	).Err()
+}
+
+func (store *VerificationStore) ConsumeEmailVerification(tokenHash string) (uint64, string, error) {
+	value, err := store.rds.GetDel(
+		context.Background(),
+		store.verificationKey(consts.REDIS_EMAIL_VERIFY_TOKEN, tokenHash),
+	).Result()
+	if err != nil {
+		return 0, "", err
+	}
+
+	uidString, email, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, "", errors.New("verification record is invalid")
+	}
+	uid, err := strconv.ParseUint(uidString, 10, 64)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return uid, email, nil
+}
+
+func (store *VerificationStore) createUserToken(tokenPrefix, userPrefix string, uid uint64, tokenHash string, expiration time.Duration) error {
+	ctx := context.Background()
+	userKey := store.verificationKey(userPrefix, strconv.FormatUint(uid, 10))
+
+	previousHash, err := store.rds.Get(ctx, userKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	tx := store.rds.TxPipeline()
+	if previousHash != "" {
+		tx.Del(ctx, store.verificationKey(tokenPrefix, previousHash))
+	}
+	tx.Set(ctx, store.verificationKey(tokenPrefix, tokenHash), uid, expiration)
+	tx.Set(ctx, userKey, tokenHash, expiration)
+
+	_, err = tx.Exec(ctx)
+	return err
+}
+
+func (store *VerificationStore) consumeUserToken(tokenPrefix, userPrefix string, tokenHash string) (uint64, error) {
+	ctx := context.Background()
+
+	uid, err := store.rds.GetDel(ctx, store.verificationKey(tokenPrefix, tokenHash)).Uint64()
+	if err != nil {
+		return 0, err
+	}
+
+	err = store.rds.Del(ctx, store.verificationKey(userPrefix, strconv.FormatUint(uid, 10))).Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return uid, nil
+}
+
+func (store *VerificationStore) CreatePasswordReset(uid uint64, tokenHash string) error {
+	return store.createUserToken(
+		consts.REDIS_PASSWORD_RESET_TOKEN,
+		consts.REDIS_PASSWORD_RESET_USER,
+		uid,
+		tokenHash,
+		consts.PASSWORD_RESET_TOKEN_EXPIRE_TIME*time.Second,
+	)
+}
+
+func (store *VerificationStore) ConsumePasswordReset(tokenHash string) (uint64, error) {
+	return store.consumeUserToken(consts.REDIS_PASSWORD_RESET_TOKEN, consts.REDIS_PASSWORD_RESET_USER, tokenHash)
+}
+
+func (store *VerificationStore) CreateAccountUnlock(uid uint64, tokenHash string) error {
+	return store.createUserToken(
+		consts.REDIS_ACCOUNT_UNLOCK_TOKEN,
+		consts.REDIS_ACCOUNT_UNLOCK_USER,
+		uid,
+		tokenHash,
+		consts.ACCOUNT_UNLOCK_TOKEN_EXPIRE_TIME*time.Second,
+	)
+}
+
+func (store *VerificationStore) ConsumeAccountUnlock(tokenHash string) (uint64, error) {
+	return store.consumeUserToken(consts.REDIS_ACCOUNT_UNLOCK_TOKEN, consts.REDIS_ACCOUNT_UNLOCK_USER, tokenHash)
+}
@@ -0,0 +1,26 @@
+package types
+
+type ContentEntityType string
+
+const (
+	CONTENT_ENTITY_MENTION ContentEntityType = "mention"
+
+	CONTENT_ENTITY_HASHTAG ContentEntityType = "hashtag"
+)
+
+type ContentSourceType string
+
+const (
+	CONTENT_SOURCE_POST ContentSourceType = "post"
+
+	CONTENT_SOURCE_COMMENT ContentSourceType = "comment"
+
+	CONTENT_SOURCE_REPLY ContentSourceType = "reply"
+)
+
+type ParsedContentEntity struct {
+	Type   ContentEntityType
+	Text   string
+	Offset int
+	Length int
+}
@@ -0,0 +1,39 @@
+package types
+
+type NotificationType string
+
+const (
+	NOTIFICATION_TYPE_POST_LIKE NotificationType = "post_like"
+
+	NOTIFICATION_TYPE_POST_COMMENT NotificationType = "post_comment"
+
+	NOTIFICATION_TYPE_COMMENT_REPLY NotificationType = "comment_reply"
+
+	NOTIFICATION_TYPE_REPLY_REPLY NotificationType = "reply_reply"
+
+	NOTIFICATION_TYPE_FOLLOW NotificationType = "follow"
+
+	NOTIFICATION_TYPE_FOLLOW_REQUEST NotificationType = "follow_request"
+
+	NOTIFICATION_TYPE_FOLLOW_ACCEPTED NotificationType = "follow_accepted"
+
+	NOTIFICATION_TYPE_MENTION NotificationType = "mention"
+
+	NOTIFICATION_TYPE_NEW_SIGN_IN NotificationType = "new_sign_in"
+
+	NOTIFICATION_TYPE_SUSPICIOUS_LOGIN NotificationType = "suspicious_login"
+)
+
+type NotificationTargetType string
+
+const (
+	NOTIFICATION_TARGET_USER NotificationTargetType = "user"
+
+	NOTIFICATION_TARGET_POST NotificationTargetType = "post"
+
+	NOTIFICATION_TARGET_COMMENT NotificationTargetType = "comment"
+
+	NOTIFICATION_TARGET_REPLY NotificationTargetType = "reply"
+
+	NOTIFICATION_TARGET_LOGIN NotificationTargetType = "login"
+)
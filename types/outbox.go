@@ -0,0 +1,27 @@
+package types
+
+type OutboxEventType string
+
+const (
+	OUTBOX_EVENT_POST_INDEX_CREATE OutboxEventType = "post_index_create"
+
+	OUTBOX_EVENT_POST_INDEX_UPDATE OutboxEventType = "post_index_update"
+
+	OUTBOX_EVENT_POST_INDEX_DELETE OutboxEventType = "post_index_delete"
+
+	OUTBOX_EVENT_USER_INDEX_CREATE OutboxEventType = "user_index_create"
+
+	OUTBOX_EVENT_USER_INDEX_UPDATE OutboxEventType = "user_index_update"
+
+	OUTBOX_EVENT_USER_INDEX_DELETE OutboxEventType = "user_index_delete"
+
+	OUTBOX_EVENT_COMMENT_INDEX_CREATE OutboxEventType = "comment_index_create"
+
+	OUTBOX_EVENT_COMMENT_INDEX_UPDATE OutboxEventType = "comment_index_update"
+
+	OUTBOX_EVENT_COMMENT_INDEX_DELETE OutboxEventType = "comment_index_delete"
+
+	OUTBOX_EVENT_POST_TIMELINE_FANOUT OutboxEventType = "post_timeline_fanout"
+
+	OUTBOX_EVENT_POST_QUOTE_CREATE OutboxEventType = "post_quote_create"
+)
@@ -5,15 +5,21 @@ type UserAuthBody struct {
 	Password string `json:"password"`
 }
 
+type UserRegisterBody struct {
+	UserAuthBody
+	Email string `json:"email"`
+}
+
 type UserUpdatePasswordBody struct {
 	UserAuthBody
 	NewPassword string `json:"new_password"`
 }
 
 type UserUpdateProfileBody struct {
-	NickName *string `json:"nickname"`
-	Birth    *uint64 `json:"birth"`
-	Gender   *string `json:"gender"`
+	NickName  *string `json:"nickname"`
+	Birth     *uint64 `json:"birth"`
+	Gender    *string `json:"gender"`
+	IsPrivate *bool   `json:"is_private"`
 }
 
 type UserCommentCreateBody struct {
@@ -37,6 +43,17 @@ type PostCreateBody struct {
 	Images  []string `json:"images" form:"images"`
 }
 
+type PostUpdateBody struct {
+	PostID  *uint64 `json:"post_id" form:"post_id"`
+	Title   string  `json:"title" form:"title"`
+	Content string  `json:"content" form:"content"`
+}
+
+type PostQuoteBody struct {
+	PostCreateBody
+	PostID *uint64 `json:"post_id" form:"post_id"`
+}
+
 type UserCommentDeleteBody struct {
 	CommentID *uint64 `json:"comment_id" form:"comment_id"`
 }
@@ -55,3 +72,59 @@ type UserReplyUpdateBody struct {
 type UserReplyDeleteBody struct {
 	ReplyID uint64 `json:"reply_id" form:"reply_id"`
 }
+
+type NotificationReadBody struct {
+	NotificationID string `json:"notification_id" form:"notification_id"`
+}
+
+type UserRefreshTokenBody struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token"`
+}
+
+type UserSessionRevokeBody struct {
+	SessionID string `json:"session_id" form:"session_id"`
+}
+
+type UserUnlockBody struct {
+	Username string `json:"username" form:"username"`
+}
+
+type UserUnlockTokenBody struct {
+	Token string `json:"token" form:"token"`
+}
+
+type UserEmailBody struct {
+	Email string `json:"email" form:"email"`
+}
+
+type UserPasswordResetBody struct {
+	Token       string `json:"token" form:"token"`
+	NewPassword string `json:"new_password" form:"new_password"`
+}
+
+type UserTwoFactorLoginBody struct {
+	ChallengeToken string `json:"challenge_token" form:"challenge_token"`
+	Code           string `json:"code" form:"code"`
+}
+
+type UserTwoFactorCodeBody struct {
+	Code string `json:"code" form:"code"`
+}
+
+type UserTwoFactorDisableBody struct {
+	Password string `json:"password" form:"password"`
+	Code     string `json:"code" form:"code"`
+}
+
+type AccountDeletionBody struct {
+	Password string `json:"password" form:"password"`
+}
+
+type SearchRebuildBody struct {
+	Restart bool `json:"restart" form:"restart"`
+}
+
+type SearchVerifyBody struct {
+	Sample int  `json:"sample" form:"sample"`
+	Repair bool `json:"repair" form:"repair"`
+}
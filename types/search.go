@@ -0,0 +1,28 @@
+package types
+
+const (
+	SEARCH_REBUILD_STATUS_IDLE = "idle"
+
+	SEARCH_REBUILD_STATUS_RUNNING = "running"
+
+	SEARCH_REBUILD_STATUS_COMPLETED = "completed"
+
+	SEARCH_REBUILD_STATUS_FAILED = "failed"
+)
+
+type SearchRebuildProgress struct {
+	Status    string `redis:"status"`
+	LastID    uint64 `redis:"last_id"`
+	Indexed   uint64 `redis:"indexed"`
+	Total     int64  `redis:"total"`
+	StartedAt int64  `redis:"started_at"`
+	UpdatedAt int64  `redis:"updated_at"`
+	Error     string `redis:"error"`
+}
+
+type SearchVerifyResult struct {
+	Sampled    int
+	MissingIDs []uint64
+	StaleIDs   []uint64
+	OrphanIDs  []uint64
+}
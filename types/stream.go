@@ -0,0 +1,34 @@
+package types
+
+type StreamEventType string
+
+const (
+	STREAM_EVENT_NOTIFICATION StreamEventType = "notification"
+
+	STREAM_EVENT_TIMELINE_POST StreamEventType = "timeline_post"
+
+	STREAM_EVENT_COMMENT_COUNT StreamEventType = "comment_count"
+
+	STREAM_EVENT_TOKEN_EXPIRED StreamEventType = "token_expired"
+)
+
+type StreamEvent struct {
+	Type StreamEventType `json:"type"`
+	Data interface{}     `json:"data"`
+}
+
+type NotificationStreamData struct {
+	Type       NotificationType       `json:"type"`
+	TargetType NotificationTargetType `json:"target_type"`
+	TargetID   uint64                 `json:"target_id"`
+	ActorID    uint64                 `json:"actor_id"`
+}
+
+type TimelinePostStreamData struct {
+	PostID uint64 `json:"post_id"`
+}
+
+type CommentCountStreamData struct {
+	PostID uint64 `json:"post_id"`
+	Count  int64  `json:"count"`
+}
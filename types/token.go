@@ -4,6 +4,31 @@ import "github.com/golang-jwt/jwt/v5"
 
 type BearerTokenClaims struct {
 	jwt.RegisteredClaims
-	UID      uint64 `json:"uid"`
-	Username string `json:"username"`
+	UID       uint64 `json:"uid"`
+	Username  string `json:"username"`
+	SessionID string `json:"sid,omitempty"`
+}
+
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+}
+
+type UserSession struct {
+	SessionID     string `redis:"session_id"`
+	RefreshHash   string `redis:"refresh_hash"`
+	PreviousHash  string `redis:"previous_hash"`
+	AccessTokenID string `redis:"access_token_id"`
+	CreatedAt     int64  `redis:"created_at"`
+	RefreshedAt   int64  `redis:"refreshed_at"`
+}
+
+type LoginChallenge struct {
+	UID         uint64 `redis:"uid"`
+	Username    string `redis:"username"`
+	IP          string `redis:"ip"`
+	Application string `redis:"application"`
+	Device      string `redis:"device"`
+	Attempts    int    `redis:"attempts"`
 }
@@ -1,27 +1,141 @@
 package encryptors
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+var (
+	ErrPasswordMismatch = errors.New("password mismatch")
+	ErrHashFormat       = errors.New("unsupported password hash format")
 )
 
-func HashPassword(password string, salt string) (string, error) {
+type argon2Params struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+var currentArgon2Params = argon2Params{
+	time:    consts.ARGON2_TIME,
+	memory:  consts.ARGON2_MEMORY,
+	threads: consts.ARGON2_THREADS,
+}
 
-	passwordWithSalt := append([]byte(password), []byte(salt)...)
+func HashPassword(password string) (string, error) {
 
-	hashedPassword, err := bcrypt.GenerateFromPassword(
-		passwordWithSalt,
-		bcrypt.DefaultCost,
-	)
+	salt := make([]byte, consts.ARGON2_SALT_LENGTH)
+	_, err := rand.Read(salt)
 	if err != nil {
 		return "", err
 	}
 
-	return string(hashedPassword), nil
+	params := currentArgon2Params
+	key := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, consts.ARGON2_KEY_LENGTH)
+
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		params.memory,
+		params.time,
+		params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
 }
 
-func CompareHashPassword(hashedPassword string, password string, salt string) error {
+func CompareHashPassword(hashedPassword string, password string, legacySalt string) error {
+
+	if !strings.HasPrefix(hashedPassword, argon2idPrefix) {
+		passwordWithSalt := append([]byte(password), []byte(legacySalt)...)
+		err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), passwordWithSalt)
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrPasswordMismatch
+		}
+		return err
+	}
+
+	params, salt, key, err := parseArgon2Hash(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	computed := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(computed, key) != 1 {
+		return ErrPasswordMismatch
+	}
+
+	return nil
+}
+
+func NeedsRehash(hashedPassword string) bool {
+
+	if !strings.HasPrefix(hashedPassword, argon2idPrefix) {
+		return true
+	}
+
+	params, _, key, err := parseArgon2Hash(hashedPassword)
+	if err != nil {
+		return true
+	}
+
+	return params != currentArgon2Params || len(key) != consts.ARGON2_KEY_LENGTH
+}
+
+func parseArgon2Hash(hashedPassword string) (argon2Params, []byte, []byte, error) {
+
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 {
+		return argon2Params{}, nil, nil, ErrHashFormat
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return argon2Params{}, nil, nil, ErrHashFormat
+	}
+
+	var params argon2Params
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads)
+	if err != nil {
+		return argon2Params{}, nil, nil, ErrHashFormat
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2Params{}, nil, nil, ErrHashFormat
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return argon2Params{}, nil, nil, ErrHashFormat
+	}
+
+	return params, salt, key, nil
+}
+
+func HashToken(token string) string {
+
+	hashed := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hashed[:])
+}
 
-	passwordWithSalt := append([]byte(password), []byte(salt)...)
+func CompareHashToken(hashedToken string, token string) bool {
 
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), passwordWithSalt)
+	return subtle.ConstantTimeCompare([]byte(hashedToken), []byte(HashToken(token))) == 1
 }
@@ -0,0 +1,109 @@
+package encryptors
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+)
+
+const (
+	sealedSecretPrefix = "v1:"
+
+	recoveryCodeHashPrefix = "hmac-sha256:"
+)
+
+var ErrSecretFormat = errors.New("unsupported sealed secret format")
+
+type SecretBox struct {
+	aead            cipher.AEAD
+	recoveryCodeKey []byte
+}
+
+func NewSecretBox(encodedKey string) (*SecretBox, error) {
+
+	if encodedKey == "" {
+		return nil, errors.New("secret key is not configured")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != consts.SECRET_KEY_LENGTH {
+		return nil, errors.New("secret key must be 32 bytes")
+	}
+
+	block, err := aes.NewCipher(deriveSecretKey(key, "totp-secret"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretBox{aead: aead, recoveryCodeKey: deriveSecretKey(key, "recovery-code")}, nil
+}
+
+func deriveSecretKey(key []byte, label string) []byte {
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(label))
+
+	return mac.Sum(nil)
+}
+
+func (box *SecretBox) Seal(plaintext string) (string, error) {
+
+	nonce := make([]byte, box.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	sealed := box.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return sealedSecretPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (box *SecretBox) Open(value string) (string, error) {
+
+	if !strings.HasPrefix(value, sealedSecretPrefix) {
+		return "", ErrSecretFormat
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, sealedSecretPrefix))
+	if err != nil || len(sealed) < box.aead.NonceSize() {
+		return "", ErrSecretFormat
+	}
+
+	nonce, ciphertext := sealed[:box.aead.NonceSize()], sealed[box.aead.NonceSize():]
+	plaintext, err := box.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func (box *SecretBox) HashRecoveryCode(code string) string {
+
+	mac := hmac.New(sha256.New, box.recoveryCodeKey)
+	mac.Write([]byte(code))
+
+	return recoveryCodeHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (box *SecretBox) CompareRecoveryCode(hashedCode string, code string) bool {
+
+	return subtle.ConstantTimeCompare([]byte(hashedCode), []byte(box.HashRecoveryCode(code))) == 1
+}
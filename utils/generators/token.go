@@ -1,6 +1,8 @@
 package generators
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -8,26 +10,47 @@ import (
 
 	"github.com/mehakhanaa/complex-micro-blog/consts"
 	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/keys"
 )
 
-func GenerateToken(uid uint64, username string) (string, *types.BearerTokenClaims, error) {
+func GenerateToken(keySet *keys.KeySet, uid uint64, username string, sessionID string) (string, *types.BearerTokenClaims, error) {
 
 	claims := &types.BearerTokenClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(consts.TOKEN_EXPIRE_DURATION * time.Second)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
-			Issuer:    consts.TOKEN_ISSUER,
+			Issuer:    keySet.Issuer,
 			Subject:   "BearerToken",
 			ID:        uuid.New().String(),
 		},
-		UID:      uid,
-		Username: username,
+		UID:       uid,
+		Username:  username,
+		SessionID: sessionID,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	tokenString, err := token.SignedString([]byte(consts.TOKEN_SECRET))
+	tokenString, err := keySet.Sign(claims)
 
 	return tokenString, claims, err
 }
+
+func GenerateSessionID() string {
+	return uuid.New().String()
+}
+
+func GenerateRefreshToken(uid uint64, sessionID string) (string, string, error) {
+
+	secret, err := GenerateSalt(consts.REFRESH_TOKEN_SECRET_LENGTH)
+	if err != nil {
+		return "", "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatUint(uid, 10))
+	sb.WriteRune('.')
+	sb.WriteString(sessionID)
+	sb.WriteRune('.')
+	sb.WriteString(secret)
+
+	return sb.String(), secret, nil
+}
@@ -0,0 +1,87 @@
+package generators
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+)
+
+func GenerateTOTPSecret() (string, error) {
+
+	randomBytes := make([]byte, consts.TOTP_SECRET_SIZE)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(randomBytes), nil
+}
+
+func GenerateTOTPCode(secret string, counter uint64) (string, error) {
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < consts.TOTP_DIGITS; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", consts.TOTP_DIGITS, value%modulo), nil
+}
+
+func GenerateTOTPURI(accountName string, secret string) string {
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", consts.TOTP_ISSUER)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(consts.TOTP_DIGITS))
+	query.Set("period", strconv.Itoa(consts.TOTP_PERIOD))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + consts.TOTP_ISSUER + ":" + accountName,
+		RawQuery: query.Encode(),
+	}
+
+	return uri.String()
+}
+
+func GenerateRecoveryCodes(count int) ([]string, error) {
+
+	codes := make([]string, count)
+	for index := range codes {
+		randomBytes := make([]byte, 5)
+		_, err := rand.Read(randomBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(randomBytes))
+		codes[index] = code[:4] + "-" + code[4:]
+	}
+
+	return codes, nil
+}
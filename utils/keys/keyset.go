@@ -0,0 +1,241 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/mehakhanaa/complex-micro-blog/configs"
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+)
+
+const (
+	ALGORITHM_HS256 = "HS256"
+
+	ALGORITHM_RS256 = "RS256"
+
+	ALGORITHM_EDDSA = "EdDSA"
+)
+
+type SigningKey struct {
+	ID          string
+	Method      jwt.SigningMethod
+	SignKey     interface{}
+	VerifyKey   interface{}
+	IsSymmetric bool
+}
+
+type KeySet struct {
+	Issuer    string
+	activeKey *SigningKey
+	keys      map[string]*SigningKey
+}
+
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+func NewKeySet(cfg *configs.Config) (*KeySet, error) {
+
+	keySet := &KeySet{
+		Issuer: cfg.Token.Issuer,
+		keys:   make(map[string]*SigningKey),
+	}
+	if keySet.Issuer == "" {
+		keySet.Issuer = consts.TOKEN_ISSUER
+	}
+
+	for _, keyConfig := range cfg.Token.Keys {
+		if keyConfig.ID == "" {
+			return nil, errors.New("token key id is required")
+		}
+		if _, ok := keySet.keys[keyConfig.ID]; ok {
+			return nil, fmt.Errorf("token key %s is duplicated", keyConfig.ID)
+		}
+
+		key, err := loadSigningKey(keyConfig)
+		if err != nil {
+			return nil, fmt.Errorf("token key %s: %w", keyConfig.ID, err)
+		}
+		keySet.keys[key.ID] = key
+	}
+
+	activeKey, ok := keySet.keys[cfg.Token.ActiveKeyID]
+	if !ok {
+		return nil, fmt.Errorf("active token key %s is not configured", cfg.Token.ActiveKeyID)
+	}
+	if activeKey.SignKey == nil {
+		return nil, fmt.Errorf("active token key %s has no private key", activeKey.ID)
+	}
+	keySet.activeKey = activeKey
+
+	return keySet, nil
+}
+
+func loadSigningKey(keyConfig configs.TokenKeyConfig) (*SigningKey, error) {
+
+	key := &SigningKey{ID: keyConfig.ID}
+
+	switch keyConfig.Algorithm {
+	case ALGORITHM_HS256:
+		if keyConfig.Secret == "" {
+			return nil, errors.New("secret is required")
+		}
+		key.Method = jwt.SigningMethodHS256
+		key.SignKey = []byte(keyConfig.Secret)
+		key.VerifyKey = []byte(keyConfig.Secret)
+		key.IsSymmetric = true
+
+	case ALGORITHM_RS256:
+		key.Method = jwt.SigningMethodRS256
+		if keyConfig.PrivateKeyPath != "" {
+			data, err := os.ReadFile(keyConfig.PrivateKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.SignKey = privateKey
+			key.VerifyKey = &privateKey.PublicKey
+		}
+		if keyConfig.PublicKeyPath != "" {
+			data, err := os.ReadFile(keyConfig.PublicKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.VerifyKey = publicKey
+		}
+
+	case ALGORITHM_EDDSA:
+		key.Method = jwt.SigningMethodEdDSA
+		if keyConfig.PrivateKeyPath != "" {
+			data, err := os.ReadFile(keyConfig.PrivateKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			privateKey, err := jwt.ParseEdPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.SignKey = privateKey
+			key.VerifyKey = privateKey.(ed25519.PrivateKey).Public()
+		}
+		if keyConfig.PublicKeyPath != "" {
+			data, err := os.ReadFile(keyConfig.PublicKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			publicKey, err := jwt.ParseEdPublicKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.VerifyKey = publicKey
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %s", keyConfig.Algorithm)
+	}
+
+	if key.VerifyKey == nil {
+		return nil, errors.New("private key path or public key path is required")
+	}
+
+	return key, nil
+}
+
+func (keySet *KeySet) Sign(claims jwt.Claims) (string, error) {
+
+	token := jwt.NewWithClaims(keySet.activeKey.Method, claims)
+	token.Header["kid"] = keySet.activeKey.ID
+
+	return token.SignedString(keySet.activeKey.SignKey)
+}
+
+func (keySet *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+
+	key := keySet.activeKey
+	if kid, ok := token.Header["kid"].(string); ok {
+		key, ok = keySet.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("token key %s is unknown", kid)
+		}
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+
+	return key.VerifyKey, nil
+}
+
+func (keySet *KeySet) JWKS() JSONWebKeySet {
+
+	jwks := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range keySet.keys {
+		if key.IsSymmetric {
+			continue
+		}
+
+		jwk, ok := newJSONWebKey(key.ID, key.Method.Alg(), key.VerifyKey)
+		if ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID
+	})
+
+	return jwks
+}
+
+func newJSONWebKey(kid, algorithm string, publicKey crypto.PublicKey) (JSONWebKey, bool) {
+
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		return JSONWebKey{
+			KeyType:   "RSA",
+			KeyID:     kid,
+			Use:       "sig",
+			Algorithm: algorithm,
+			N:         base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}, true
+	case ed25519.PublicKey:
+		return JSONWebKey{
+			KeyType:   "OKP",
+			KeyID:     kid,
+			Use:       "sig",
+			Algorithm: algorithm,
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(publicKey),
+		}, true
+	}
+
+	return JSONWebKey{}, false
+}
@@ -0,0 +1,54 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+var (
+	mentionPattern = regexp.MustCompile(`@[a-z0-9_]+`)
+	hashtagPattern = regexp.MustCompile(`#[\p{L}\p{N}_]+`)
+)
+
+func ParseContentEntities(content string) []types.ParsedContentEntity {
+
+	var entities []types.ParsedContentEntity
+
+	for _, match := range mentionPattern.FindAllStringIndex(content, -1) {
+		if !isEntityBoundary(content, match[0]) {
+			continue
+		}
+		entities = append(entities, types.ParsedContentEntity{
+			Type:   types.CONTENT_ENTITY_MENTION,
+			Text:   content[match[0]+1 : match[1]],
+			Offset: utf8.RuneCountInString(content[:match[0]]),
+			Length: utf8.RuneCountInString(content[match[0]:match[1]]),
+		})
+	}
+
+	for _, match := range hashtagPattern.FindAllStringIndex(content, -1) {
+		if !isEntityBoundary(content, match[0]) {
+			continue
+		}
+		entities = append(entities, types.ParsedContentEntity{
+			Type:   types.CONTENT_ENTITY_HASHTAG,
+			Text:   strings.ToLower(content[match[0]+1 : match[1]]),
+			Offset: utf8.RuneCountInString(content[:match[0]]),
+			Length: utf8.RuneCountInString(content[match[0]:match[1]]),
+		})
+	}
+
+	return entities
+}
+
+func isEntityBoundary(content string, index int) bool {
+	if index == 0 {
+		return true
+	}
+	previous, _ := utf8.DecodeLastRuneInString(content[:index])
+	return !unicode.IsLetter(previous) && !unicode.IsDigit(previous) && previous != '_'
+}
@@ -1,18 +1,35 @@
 package parsers
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+
 	"github.com/golang-jwt/jwt/v5"
 
-	"github.com/mehakhanaa/complex-micro-blog/consts"
 	"github.com/mehakhanaa/complex-micro-blog/types"
+	"github.com/mehakhanaa/complex-micro-blog/utils/keys"
 )
 
-func ParseToken(token string) (*types.BearerTokenClaims, error) {
+func ParseToken(keySet *keys.KeySet, token string) (*types.BearerTokenClaims, error) {
 
 	claims := new(types.BearerTokenClaims)
-	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(consts.TOKEN_SECRET), nil
-	})
+	_, err := jwt.ParseWithClaims(token, claims, keySet.Keyfunc, jwt.WithIssuer(keySet.Issuer))
 
 	return claims, err
 }
+
+func ParseRefreshToken(token string) (uint64, string, string, error) {
+
+	parts := strings.SplitN(token, ".", 3)
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return 0, "", "", errors.New("refresh token is invalid")
+	}
+
+	uid, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", "", errors.New("refresh token is invalid")
+	}
+
+	return uid, parts[1], parts[2], nil
+}
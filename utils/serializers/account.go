@@ -0,0 +1,172 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/mehakhanaa/complex-micro-blog/models"
+)
+
+type AccountExportPost struct {
+	PostID       uint64   `json:"post_id"`
+	ParentPostID *uint64  `json:"parent_post_id"`
+	Title        string   `json:"title"`
+	Content      string   `json:"content"`
+	Images       []string `json:"images"`
+	IsPublic     bool     `json:"is_public"`
+	IPAddress    *string  `json:"ip_address"`
+	CreatedAt    int64    `json:"created_at"`
+	UpdatedAt    int64    `json:"updated_at"`
+}
+
+type AccountExportComment struct {
+	CommentID uint64 `json:"comment_id"`
+	PostID    uint64 `json:"post_id"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+type AccountExportReply struct {
+	ReplyID       uint64  `json:"reply_id"`
+	CommentID     uint64  `json:"comment_id"`
+	ParentReplyID *uint64 `json:"parent_reply_id"`
+	Content       string  `json:"content"`
+	CreatedAt     int64   `json:"created_at"`
+	UpdatedAt     int64   `json:"updated_at"`
+}
+
+type AccountExportReaction struct {
+	TargetID  int64  `json:"target_id"`
+	Type      string `json:"type"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type AccountExportForward struct {
+	PostID      int64  `json:"post_id"`
+	QuotePostID *int64 `json:"quote_post_id"`
+	ForwardedAt int64  `json:"forwarded_at"`
+}
+
+type AccountExportFollow struct {
+	UID        uint64 `json:"uid"`
+	FollowedAt int64  `json:"followed_at"`
+}
+
+type AccountExport struct {
+	ExportedAt    int64                   `json:"exported_at"`
+	Profile       *UserProfileData        `json:"profile"`
+	Email         *string                 `json:"email"`
+	EmailVerified bool                    `json:"email_verified"`
+	PendingEmail  *string                 `json:"pending_email"`
+	TwoFactor     bool                    `json:"two_factor_enabled"`
+	Posts         []AccountExportPost     `json:"posts"`
+	Comments      []AccountExportComment  `json:"comments"`
+	Replies       []AccountExportReply    `json:"replies"`
+	Reactions     []AccountExportReaction `json:"reactions"`
+	Forwards      []AccountExportForward  `json:"forwards"`
+	Followings    []AccountExportFollow   `json:"followings"`
+	Followers     []AccountExportFollow   `json:"followers"`
+	LoginHistory  []UserLoginHistoryData  `json:"login_history"`
+}
+
+func NewAccountExport(archive *models.AccountArchive, exportedAt time.Time) AccountExport {
+	export := AccountExport{
+		ExportedAt:    exportedAt.Unix(),
+		Profile:       NewUserProfileData(&archive.User),
+		Email:         archive.AuthInfo.Email,
+		EmailVerified: archive.AuthInfo.EmailVerified,
+		PendingEmail:  archive.AuthInfo.PendingEmail,
+		TwoFactor:     archive.AuthInfo.TOTPEnabled,
+		Posts:         make([]AccountExportPost, len(archive.Posts)),
+		Comments:      make([]AccountExportComment, len(archive.Comments)),
+		Replies:       make([]AccountExportReply, len(archive.Replies)),
+		Reactions:     make([]AccountExportReaction, 0, len(archive.Likes)+len(archive.Favourites)+len(archive.CommentRates)),
+		Forwards:      make([]AccountExportForward, len(archive.Forwards)),
+		Followings:    make([]AccountExportFollow, len(archive.Followings)),
+		Followers:     make([]AccountExportFollow, len(archive.Followers)),
+		LoginHistory:  NewUserLoginHistoryResponse(archive.LoginLogs).Logs,
+	}
+
+	for index, post := range archive.Posts {
+		export.Posts[index] = AccountExportPost{
+			PostID:       uint64(post.ID),
+			ParentPostID: post.ParentPostID,
+			Title:        post.Title,
+			Content:      post.Content,
+			Images:       post.Images,
+			IsPublic:     post.IsPublic,
+			IPAddress:    post.IpAddrress,
+			CreatedAt:    post.CreatedAt.Unix(),
+			UpdatedAt:    post.UpdatedAt.Unix(),
+		}
+	}
+	for index, comment := range archive.Comments {
+		export.Comments[index] = AccountExportComment{
+			CommentID: uint64(comment.ID),
+			PostID:    comment.PostID,
+			Content:   comment.Content,
+			CreatedAt: comment.CreatedAt.Unix(),
+			UpdatedAt: comment.UpdatedAt.Unix(),
+		}
+	}
+	for index, reply := range archive.Replies {
+		export.Replies[index] = AccountExportReply{
+			ReplyID:       uint64(reply.ID),
+			CommentID:     reply.CommentID,
+			ParentReplyID: reply.ParentReplyID,
+			Content:       reply.Content,
+			CreatedAt:     reply.CreatedAt.Unix(),
+			UpdatedAt:     reply.UpdatedAt.Unix(),
+		}
+	}
+	for _, like := range archive.Likes {
+		export.Reactions = append(export.Reactions, AccountExportReaction{
+			TargetID:  like.PostID,
+			Type:      "post_like",
+			CreatedAt: like.LikedAt.Unix(),
+		})
+	}
+	for _, favourite := range archive.Favourites {
+		export.Reactions = append(export.Reactions, AccountExportReaction{
+			TargetID:  favourite.PostID,
+			Type:      "post_favourite",
+			CreatedAt: favourite.FavouritedAt.Unix(),
+		})
+	}
+	for _, rate := range archive.CommentRates {
+		export.Reactions = append(export.Reactions, AccountExportReaction{
+			TargetID:  int64(rate.CommentID),
+			Type:      "comment_" + rate.Rate,
+			CreatedAt: rate.RatedAt.Unix(),
+		})
+	}
+	for index, forward := range archive.Forwards {
+		export.Forwards[index] = AccountExportForward{
+			PostID:      forward.PostID,
+			QuotePostID: forward.QuotePostID,
+			ForwardedAt: forward.ForwardedAt.Unix(),
+		}
+	}
+	for index, following := range archive.Followings {
+		export.Followings[index] = AccountExportFollow{
+			UID:        following.FollowedID,
+			FollowedAt: following.FollowedAt.Unix(),
+		}
+	}
+	for index, follower := range archive.Followers {
+		export.Followers[index] = AccountExportFollow{
+			UID:        follower.UserID,
+			FollowedAt: follower.FollowedAt.Unix(),
+		}
+	}
+
+	return export
+}
+
+type AccountDeletionResponse struct {
+	ScheduledAt int64 `json:"scheduled_at"`
+}
+
+func NewAccountDeletionResponse(accountDeletion *models.AccountDeletion) AccountDeletionResponse {
+	return AccountDeletionResponse{ScheduledAt: accountDeletion.ScheduledAt.Unix()}
+}
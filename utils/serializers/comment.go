@@ -17,18 +17,19 @@ func NewCommentListResponse(commentInfos []models.CommentInfo) CommentListRespon
 }
 
 type CommentDetailResponse struct {
-	CommentID     uint64 `json:"comment_id"`
-	PostID        uint64 `json:"post_id"`
-	PosterUID     uint64 `json:"poster_uid"`
-	PostTimestamp int64  `json:"post_timestamp"`
-	Content       string `json:"content"`
-	Likes         int64  `json:"likes"`
-	Replies       int    `json:"replies"`
-	Is_liked      bool   `json:"is_liked"`
-	Is_disliked   bool   `json:"is_disliked"`
+	CommentID     uint64                  `json:"comment_id"`
+	PostID        uint64                  `json:"post_id"`
+	PosterUID     uint64                  `json:"poster_uid"`
+	PostTimestamp int64                   `json:"post_timestamp"`
+	Content       string                  `json:"content"`
+	Likes         int64                   `json:"likes"`
+	Replies       int                     `json:"replies"`
+	Is_liked      bool                    `json:"is_liked"`
+	Is_disliked   bool                    `json:"is_disliked"`
+	Entities      []ContentEntityResponse `json:"entities"`
 }
 
-func NewCommentDetailResponse(comment models.CommentInfo, likeCount int64) *CommentDetailResponse {
+func NewCommentDetailResponse(comment models.CommentInfo, likeCount int64, entities []models.ContentEntity) *CommentDetailResponse {
 
 	profileData := &CommentDetailResponse{
 		CommentID:     uint64(comment.ID),
@@ -37,6 +38,7 @@ func NewCommentDetailResponse(comment models.CommentInfo, likeCount int64) *Comm
 		PostTimestamp: comment.CreatedAt.Unix(),
 		Content:       comment.Content,
 		Likes:         likeCount,
+		Entities:      NewContentEntityListResponse(entities),
 	}
 
 	return profileData
@@ -0,0 +1,28 @@
+package serializers
+
+import (
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type ContentEntityResponse struct {
+	Type   types.ContentEntityType `json:"type"`
+	Text   string                  `json:"text"`
+	Offset int                     `json:"offset"`
+	Length int                     `json:"length"`
+	UID    *uint64                 `json:"uid,omitempty"`
+}
+
+func NewContentEntityListResponse(entities []models.ContentEntity) []ContentEntityResponse {
+	entityList := make([]ContentEntityResponse, len(entities))
+	for index, entity := range entities {
+		entityList[index] = ContentEntityResponse{
+			Type:   entity.Type,
+			Text:   entity.Text,
+			Offset: entity.Offset,
+			Length: entity.Length,
+			UID:    entity.MentionUID,
+		}
+	}
+	return entityList
+}
@@ -23,3 +23,31 @@ func NewFollowerListResponse(followInfos []models.FollowInfo) FollowListResponse
 	}
 	return FollowListResponse{IDs: ids}
 }
+
+type FollowResultResponse struct {
+	Pending bool `json:"pending"`
+}
+
+func NewFollowResultResponse(pending bool) FollowResultResponse {
+	return FollowResultResponse{Pending: pending}
+}
+
+type FollowRequestData struct {
+	UID         uint64 `json:"uid"`
+	RequestedAt int64  `json:"requested_at"`
+}
+
+type FollowRequestListResponse struct {
+	Requests []FollowRequestData `json:"requests"`
+}
+
+func NewFollowRequestListResponse(followRequests []models.FollowRequestInfo) FollowRequestListResponse {
+	resp := FollowRequestListResponse{Requests: make([]FollowRequestData, len(followRequests))}
+	for index, followRequest := range followRequests {
+		resp.Requests[index] = FollowRequestData{
+			UID:         followRequest.UserID,
+			RequestedAt: followRequest.RequestedAt.Unix(),
+		}
+	}
+	return resp
+}
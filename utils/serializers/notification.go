@@ -0,0 +1,114 @@
+package serializers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type NotificationResponse struct {
+	ID         string                       `json:"id"`
+	Type       types.NotificationType       `json:"type"`
+	TargetType types.NotificationTargetType `json:"target_type"`
+	TargetID   uint64                       `json:"target_id"`
+	ActorIDs   []uint64                     `json:"actor_ids"`
+	ActorCount int                          `json:"actor_count"`
+	Summary    string                       `json:"summary"`
+	IsRead     bool                         `json:"is_read"`
+	Timestamp  int64                        `json:"timestamp"`
+	Cursor     string                       `json:"cursor"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+}
+
+func NewNotificationListResponse(notifications []models.NotificationInfo, actorPreviewLength int) NotificationListResponse {
+
+	resp := NotificationListResponse{
+		Notifications: make([]NotificationResponse, len(notifications)),
+	}
+	for index, notification := range notifications {
+
+		actorIDs := notification.ActorIDs
+		if len(actorIDs) > actorPreviewLength {
+			actorIDs = actorIDs[len(actorIDs)-actorPreviewLength:]
+		}
+
+		resp.Notifications[index] = NotificationResponse{
+			ID:         notification.ID.Hex(),
+			Type:       notification.Type,
+			TargetType: notification.TargetType,
+			TargetID:   notification.TargetID,
+			ActorIDs:   actorIDs,
+			ActorCount: len(notification.ActorIDs),
+			Summary:    newNotificationSummary(notification.Type, notification.TargetType, len(notification.ActorIDs)),
+			IsRead:     notification.IsRead,
+			Timestamp:  notification.UpdatedAt.Unix(),
+			Cursor:     newNotificationCursor(notification),
+		}
+	}
+
+	return resp
+}
+
+func newNotificationCursor(notification models.NotificationInfo) string {
+
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatInt(notification.UpdatedAt.UnixMilli(), 10))
+	sb.WriteRune('_')
+	sb.WriteString(notification.ID.Hex())
+	return sb.String()
+}
+
+func newNotificationSummary(notificationType types.NotificationType, targetType types.NotificationTargetType, actorCount int) string {
+
+	switch notificationType {
+	case types.NOTIFICATION_TYPE_NEW_SIGN_IN:
+		return "new sign-in to your account"
+	case types.NOTIFICATION_TYPE_SUSPICIOUS_LOGIN:
+		return "suspicious sign-in attempt"
+	}
+
+	var sb strings.Builder
+	if actorCount == 1 {
+		sb.WriteString("someone")
+	} else {
+		sb.WriteString(strconv.Itoa(actorCount))
+		sb.WriteString(" people")
+	}
+
+	switch notificationType {
+	case types.NOTIFICATION_TYPE_POST_LIKE:
+		sb.WriteString(" liked your post")
+	case types.NOTIFICATION_TYPE_POST_COMMENT:
+		sb.WriteString(" commented on your post")
+	case types.NOTIFICATION_TYPE_COMMENT_REPLY:
+		sb.WriteString(" replied to your comment")
+	case types.NOTIFICATION_TYPE_REPLY_REPLY:
+		sb.WriteString(" replied to your reply")
+	case types.NOTIFICATION_TYPE_FOLLOW:
+		sb.WriteString(" followed you")
+	case types.NOTIFICATION_TYPE_FOLLOW_REQUEST:
+		sb.WriteString(" requested to follow you")
+	case types.NOTIFICATION_TYPE_FOLLOW_ACCEPTED:
+		sb.WriteString(" accepted your follow request")
+	case types.NOTIFICATION_TYPE_MENTION:
+		sb.WriteString(" mentioned you in a ")
+		sb.WriteString(string(targetType))
+	default:
+		sb.WriteString(" interacted with you")
+	}
+
+	return sb.String()
+}
+
+type UnreadCountResponse struct {
+	Count int64 `json:"count"`
+}
+
+func NewUnreadCountResponse(count int64) UnreadCountResponse {
+	return UnreadCountResponse{Count: count}
+}
@@ -0,0 +1,56 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type OutboxDeadEventResponse struct {
+	ID          uint                  `json:"id"`
+	Type        types.OutboxEventType `json:"type"`
+	AggregateID uint64                `json:"aggregate_id"`
+	Attempts    int                   `json:"attempts"`
+	LastError   string                `json:"last_error"`
+	CreatedAt   int64                 `json:"created_at"`
+	DeadAt      int64                 `json:"dead_at"`
+}
+
+type OutboxLagResponse struct {
+	Pending         int64                     `json:"pending"`
+	Retrying        int64                     `json:"retrying"`
+	Dead            int64                     `json:"dead"`
+	OldestCreatedAt *int64                    `json:"oldest_created_at"`
+	LagSeconds      int64                     `json:"lag_seconds"`
+	DeadEvents      []OutboxDeadEventResponse `json:"dead_events"`
+}
+
+func NewOutboxLagResponse(pending, retrying, dead int64, oldest *time.Time, deadEvents []models.OutboxEvent, now time.Time) OutboxLagResponse {
+	resp := OutboxLagResponse{
+		Pending:    pending,
+		Retrying:   retrying,
+		Dead:       dead,
+		DeadEvents: make([]OutboxDeadEventResponse, 0, len(deadEvents)),
+	}
+	if oldest != nil {
+		oldestCreatedAt := oldest.Unix()
+		resp.OldestCreatedAt = &oldestCreatedAt
+		resp.LagSeconds = int64(now.Sub(*oldest).Seconds())
+	}
+	for _, event := range deadEvents {
+		deadEvent := OutboxDeadEventResponse{
+			ID:          event.ID,
+			Type:        event.Type,
+			AggregateID: event.AggregateID,
+			Attempts:    event.Attempts,
+			LastError:   event.LastError,
+			CreatedAt:   event.CreatedAt.Unix(),
+		}
+		if event.DeadAt != nil {
+			deadEvent.DeadAt = event.DeadAt.Unix()
+		}
+		resp.DeadEvents = append(resp.DeadEvents, deadEvent)
+	}
+	return resp
+}
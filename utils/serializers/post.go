@@ -12,20 +12,30 @@ func NewPostListResponse(posts []int64) *PostListResponse {
 	return &PostListResponse{IDs: posts}
 }
 
+type ParentPostReference struct {
+	PostID    uint64 `json:"post_id"`
+	UID       uint64 `json:"uid,omitempty"`
+	Title     string `json:"title,omitempty"`
+	IsDeleted bool   `json:"is_deleted"`
+	IsHidden  bool   `json:"is_hidden"`
+}
+
 type PostDetailResponse struct {
-	CommentID    uint64   `json:"comment_id"`
-	UID          uint64   `json:"uid"`
-	Timestamp    int64    `json:"timestamp"`
-	Title        string   `json:"title"`
-	Content      string   `json:"content"`
-	ParentPostID *uint64  `json:"parent_post_id"`
-	Images       []string `json:"images"`
-	Like         int64    `json:"like"`
-	Favourite    int64    `json:"favourite"`
-	Farward      int      `json:"farward"`
+	CommentID    uint64                  `json:"comment_id"`
+	UID          uint64                  `json:"uid"`
+	Timestamp    int64                   `json:"timestamp"`
+	Title        string                  `json:"title"`
+	Content      string                  `json:"content"`
+	ParentPostID *uint64                 `json:"parent_post_id"`
+	ParentPost   *ParentPostReference    `json:"parent_post"`
+	Images       []string                `json:"images"`
+	Like         int64                   `json:"like"`
+	Favourite    int64                   `json:"favourite"`
+	Farward      int64                   `json:"farward"`
+	Entities     []ContentEntityResponse `json:"entities"`
 }
 
-func NewPostDetailResponse(post models.PostInfo, likeCount, favouriteCount int64) *PostDetailResponse {
+func NewPostDetailResponse(post models.PostInfo, parentPost *models.PostInfo, parentHidden bool, likeCount, favouriteCount, forwardCount int64, entities []models.ContentEntity) *PostDetailResponse {
 
 	profileData := &PostDetailResponse{
 		CommentID:    uint64(post.ID),
@@ -36,7 +46,27 @@ func NewPostDetailResponse(post models.PostInfo, likeCount, favouriteCount int64
 		ParentPostID: post.ParentPostID,
 		Like:         likeCount,
 		Favourite:    favouriteCount,
-		Farward:      len(post.Farward),
+		Farward:      forwardCount,
+		Entities:     NewContentEntityListResponse(entities),
+	}
+	if post.ParentPostID != nil {
+		if parentPost != nil {
+			profileData.ParentPost = &ParentPostReference{
+				PostID: uint64(parentPost.ID),
+				UID:    parentPost.UID,
+				Title:  parentPost.Title,
+			}
+		} else if parentHidden {
+			profileData.ParentPost = &ParentPostReference{
+				PostID:   *post.ParentPostID,
+				IsHidden: true,
+			}
+		} else {
+			profileData.ParentPost = &ParentPostReference{
+				PostID:    *post.ParentPostID,
+				IsDeleted: true,
+			}
+		}
 	}
 	for _, image := range post.Images {
 		profileData.Images = append(profileData.Images, "/resources/image/"+image)
@@ -69,13 +99,15 @@ type PostUserStatus struct {
 	UID       uint64 `json:"uid"`
 	Like      bool   `json:"like"`
 	Favourite bool   `json:"favourite"`
+	Repost    bool   `json:"repost"`
 }
 
-func NewPostUserStatus(postID uint64, uid uint64, like bool, favourite bool) PostUserStatus {
+func NewPostUserStatus(postID uint64, uid uint64, like bool, favourite bool, repost bool) PostUserStatus {
 	return PostUserStatus{
 		PostID:    postID,
 		UID:       uid,
 		Like:      like,
 		Favourite: favourite,
+		Repost:    repost,
 	}
 }
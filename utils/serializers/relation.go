@@ -0,0 +1,25 @@
+package serializers
+
+import (
+	"github.com/mehakhanaa/complex-micro-blog/models"
+)
+
+type RelationData struct {
+	UID       uint64 `json:"uid"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type RelationListResponse struct {
+	Users []RelationData `json:"users"`
+}
+
+func NewRelationListResponse(relations []models.RelationInfo) RelationListResponse {
+	resp := RelationListResponse{Users: make([]RelationData, len(relations))}
+	for index, relation := range relations {
+		resp.Users[index] = RelationData{
+			UID:       relation.TargetID,
+			CreatedAt: relation.CreatedAt.Unix(),
+		}
+	}
+	return resp
+}
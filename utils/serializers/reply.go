@@ -11,15 +11,16 @@ func NewReplyListResponse(replies []uint64) ReplyListResponse {
 }
 
 type ReplyDetailResponse struct {
-	CreateTime     int64   `json:"create_time"`
-	CommentID      uint64  `json:"comment_id"`
-	UID            uint64  `json:"uid"`
-	ParentReplyID  *uint64 `json:"parent_reply_id"`
-	ParentReplyUID *uint64 `json:"parent_reply_uid"`
-	Content        string  `json:"content"`
+	CreateTime     int64                   `json:"create_time"`
+	CommentID      uint64                  `json:"comment_id"`
+	UID            uint64                  `json:"uid"`
+	ParentReplyID  *uint64                 `json:"parent_reply_id"`
+	ParentReplyUID *uint64                 `json:"parent_reply_uid"`
+	Content        string                  `json:"content"`
+	Entities       []ContentEntityResponse `json:"entities"`
 }
 
-func NewReplyDetailResponse(reply models.ReplyInfo) ReplyDetailResponse {
+func NewReplyDetailResponse(reply models.ReplyInfo, entities []models.ContentEntity) ReplyDetailResponse {
 
 	profileData := ReplyDetailResponse{
 		CreateTime:     reply.CreatedAt.Unix(),
@@ -28,6 +29,7 @@ func NewReplyDetailResponse(reply models.ReplyInfo) ReplyDetailResponse {
 		ParentReplyID:  reply.ParentReplyID,
 		ParentReplyUID: reply.ParentReplyUID,
 		Content:        reply.Content,
+		Entities:       NewContentEntityListResponse(entities),
 	}
 
 	return profileData
@@ -0,0 +1,121 @@
+package serializers
+
+import (
+	"strings"
+
+	"github.com/mehakhanaa/complex-micro-blog/models"
+	search "github.com/mehakhanaa/complex-micro-blog/proto"
+	"github.com/mehakhanaa/complex-micro-blog/types"
+)
+
+type SearchHitData struct {
+	ID        int64   `json:"id"`
+	Score     float64 `json:"score"`
+	Highlight string  `json:"highlight"`
+}
+
+type SearchHitListResponse struct {
+	IDs   []int64         `json:"ids"`
+	Hits  []SearchHitData `json:"hits"`
+	Total uint64          `json:"total"`
+}
+
+func NewSearchHitListResponse(hits []*search.SearchHit, total uint64) SearchHitListResponse {
+	resp := SearchHitListResponse{
+		IDs:   make([]int64, len(hits)),
+		Hits:  make([]SearchHitData, len(hits)),
+		Total: total,
+	}
+	for index, hit := range hits {
+		resp.IDs[index] = hit.Id
+		resp.Hits[index] = SearchHitData{
+			ID:        hit.Id,
+			Score:     hit.Score,
+			Highlight: hit.Highlight,
+		}
+	}
+	return resp
+}
+
+type UserSearchData struct {
+	UID      uint64 `json:"uid"`
+	Username string `json:"username"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar_url"`
+}
+
+type UserSearchResponse struct {
+	Users []UserSearchData `json:"users"`
+}
+
+func NewUserSearchResponse(users []models.UserInfo) UserSearchResponse {
+	resp := UserSearchResponse{Users: make([]UserSearchData, len(users))}
+	for index, user := range users {
+		data := UserSearchData{
+			UID:      uint64(user.ID),
+			Username: user.UserName,
+			Nickname: user.UserName,
+		}
+		if user.NickName != nil {
+			data.Nickname = *user.NickName
+		}
+
+		var sb strings.Builder
+		sb.WriteString("/resources/avatar/")
+		sb.WriteString(user.Avatar)
+		data.Avatar = sb.String()
+
+		resp.Users[index] = data
+	}
+	return resp
+}
+
+type SearchRebuildProgressResponse struct {
+	Status    string `json:"status"`
+	LastID    uint64 `json:"last_id"`
+	Indexed   uint64 `json:"indexed"`
+	Total     int64  `json:"total"`
+	StartedAt int64  `json:"started_at"`
+	UpdatedAt int64  `json:"updated_at"`
+	Error     string `json:"error"`
+}
+
+func NewSearchRebuildProgressResponse(progress *types.SearchRebuildProgress) SearchRebuildProgressResponse {
+	return SearchRebuildProgressResponse{
+		Status:    progress.Status,
+		LastID:    progress.LastID,
+		Indexed:   progress.Indexed,
+		Total:     progress.Total,
+		StartedAt: progress.StartedAt,
+		UpdatedAt: progress.UpdatedAt,
+		Error:     progress.Error,
+	}
+}
+
+type SearchVerifyResponse struct {
+	Sampled    int      `json:"sampled"`
+	MissingIDs []uint64 `json:"missing_ids"`
+	StaleIDs   []uint64 `json:"stale_ids"`
+	OrphanIDs  []uint64 `json:"orphan_ids"`
+	Repaired   bool     `json:"repaired"`
+}
+
+func NewSearchVerifyResponse(result *types.SearchVerifyResult, repaired bool) SearchVerifyResponse {
+	resp := SearchVerifyResponse{
+		Sampled:    result.Sampled,
+		MissingIDs: result.MissingIDs,
+		StaleIDs:   result.StaleIDs,
+		OrphanIDs:  result.OrphanIDs,
+		Repaired:   repaired,
+	}
+	if resp.MissingIDs == nil {
+		resp.MissingIDs = []uint64{}
+	}
+	if resp.StaleIDs == nil {
+		resp.StaleIDs = []uint64{}
+	}
+	if resp.OrphanIDs == nil {
+		resp.OrphanIDs = []uint64{}
+	}
+	return resp
+}
@@ -0,0 +1,61 @@
+package serializers
+
+import (
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type TrendingPost struct {
+	PostID uint64  `json:"post_id"`
+	Score  float64 `json:"score"`
+}
+
+type TrendingPostListResponse struct {
+	Window string         `json:"window"`
+	Posts  []TrendingPost `json:"posts"`
+}
+
+func NewTrendingPostListResponse(window string, posts []redis.Z) TrendingPostListResponse {
+	resp := TrendingPostListResponse{
+		Window: window,
+		Posts:  make([]TrendingPost, 0, len(posts)),
+	}
+	for _, post := range posts {
+		member, ok := post.Member.(string)
+		if !ok {
+			continue
+		}
+		postID, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		resp.Posts = append(resp.Posts, TrendingPost{PostID: postID, Score: post.Score})
+	}
+	return resp
+}
+
+type TrendingTag struct {
+	Tag   string  `json:"tag"`
+	Score float64 `json:"score"`
+}
+
+type TrendingTagListResponse struct {
+	Window string        `json:"window"`
+	Tags   []TrendingTag `json:"tags"`
+}
+
+func NewTrendingTagListResponse(window string, tags []redis.Z) TrendingTagListResponse {
+	resp := TrendingTagListResponse{
+		Window: window,
+		Tags:   make([]TrendingTag, 0, len(tags)),
+	}
+	for _, tag := range tags {
+		member, ok := tag.Member.(string)
+		if !ok {
+			continue
+		}
+		resp.Tags = append(resp.Tags, TrendingTag{Tag: member, Score: tag.Score})
+	}
+	return resp
+}
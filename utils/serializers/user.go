@@ -4,16 +4,18 @@ import (
 	"strings"
 
 	"github.com/mehakhanaa/complex-micro-blog/models"
+	"github.com/mehakhanaa/complex-micro-blog/types"
 )
 
 type UserProfileData struct {
-	UID      uint64  `json:"uid"`
-	Username string  `json:"username"`
-	Nickname string  `json:"nickname"`
-	Avatar   string  `json:"avatar_url"`
-	Birth    *int64  `json:"birth"`
-	Gender   *string `json:"gender"`
-	Level    uint64  `json:"level"`
+	UID       uint64  `json:"uid"`
+	Username  string  `json:"username"`
+	Nickname  string  `json:"nickname"`
+	Avatar    string  `json:"avatar_url"`
+	Birth     *int64  `json:"birth"`
+	Gender    *string `json:"gender"`
+	Level     uint64  `json:"level"`
+	IsPrivate bool    `json:"is_private"`
 }
 
 func NewUserProfileData(model *models.UserInfo) *UserProfileData {
@@ -46,14 +48,121 @@ func NewUserProfileData(model *models.UserInfo) *UserProfileData {
 		profile.Gender = nil
 	}
 	profile.Level = model.Level
+	profile.IsPrivate = model.IsPrivate
 
 	return profile
 }
 
 type UserToken struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
 
-func NewUserToken(token string) *UserToken {
-	return &UserToken{Token: token}
+func NewUserToken(tokenPair types.TokenPair) *UserToken {
+	return &UserToken{
+		Token:        tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		ExpiresIn:    tokenPair.ExpiresIn,
+	}
+}
+
+type UserSessionData struct {
+	SessionID   string `json:"session_id"`
+	Device      string `json:"device"`
+	Application string `json:"application"`
+	IP          string `json:"ip"`
+	CreatedAt   int64  `json:"created_at"`
+	RefreshedAt int64  `json:"refreshed_at"`
+	IsCurrent   bool   `json:"is_current"`
+}
+
+type UserSessionListResponse struct {
+	Sessions []UserSessionData `json:"sessions"`
+}
+
+func NewUserSessionListResponse(sessions []types.UserSession, loginLogs map[string]*models.UserLoginLog, currentSessionID string) UserSessionListResponse {
+	resp := UserSessionListResponse{Sessions: make([]UserSessionData, len(sessions))}
+	for index, session := range sessions {
+		data := UserSessionData{
+			SessionID:   session.SessionID,
+			Device:      "unknown",
+			Application: "unknown",
+			CreatedAt:   session.CreatedAt,
+			RefreshedAt: session.RefreshedAt,
+			IsCurrent:   session.SessionID == currentSessionID,
+		}
+		if loginLog, ok := loginLogs[session.SessionID]; ok {
+			data.Device = loginLog.Device
+			data.Application = loginLog.Application
+			data.IP = loginLog.LoginIP
+		}
+		resp.Sessions[index] = data
+	}
+	return resp
+}
+
+type UserLoginHistoryData struct {
+	ID          uint64 `json:"id"`
+	LoginTime   int64  `json:"login_time"`
+	IP          string `json:"ip"`
+	Device      string `json:"device"`
+	Application string `json:"application"`
+	IsSucceed   bool   `json:"is_succeed"`
+	Reason      string `json:"reason"`
+	IsFlagged   bool   `json:"is_flagged"`
+	FlagReason  string `json:"flag_reason"`
+}
+
+type UserLoginHistoryResponse struct {
+	Logs []UserLoginHistoryData `json:"logs"`
+}
+
+func NewUserLoginHistoryResponse(loginLogs []models.UserLoginLog) UserLoginHistoryResponse {
+	resp := UserLoginHistoryResponse{Logs: make([]UserLoginHistoryData, len(loginLogs))}
+	for index, loginLog := range loginLogs {
+		resp.Logs[index] = UserLoginHistoryData{
+			ID:          uint64(loginLog.ID),
+			LoginTime:   loginLog.LoginTime.Unix(),
+			IP:          loginLog.LoginIP,
+			Device:      loginLog.Device,
+			Application: loginLog.Application,
+			IsSucceed:   loginLog.IsSucceed,
+			Reason:      loginLog.Reason,
+			IsFlagged:   loginLog.IsFlagged,
+			FlagReason:  loginLog.FlagReason,
+		}
+	}
+	return resp
+}
+
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int64  `json:"expires_in"`
+}
+
+func NewTwoFactorChallenge(challengeToken string, expiresIn int64) TwoFactorChallenge {
+	return TwoFactorChallenge{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+		ExpiresIn:         expiresIn,
+	}
+}
+
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+func NewTwoFactorEnrollment(secret string, uri string) TwoFactorEnrollment {
+	return TwoFactorEnrollment{Secret: secret, URI: uri}
+}
+
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+func NewRecoveryCodes(codes []string) RecoveryCodes {
+	return RecoveryCodes{Codes: codes}
 }
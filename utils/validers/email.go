@@ -0,0 +1,16 @@
+package validers
+
+import "net/mail"
+
+func IsValidEmail(email string) bool {
+	if len(email) > 254 {
+		return false
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil {
+		return false
+	}
+
+	return address.Address == email
+}
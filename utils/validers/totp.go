@@ -0,0 +1,31 @@
+package validers
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"github.com/mehakhanaa/complex-micro-blog/consts"
+	"github.com/mehakhanaa/complex-micro-blog/utils/generators"
+)
+
+func ValidateTOTPCode(secret string, code string, now time.Time) (uint64, bool) {
+
+	if len(code) != consts.TOTP_DIGITS {
+		return 0, false
+	}
+
+	current := uint64(now.Unix()) / consts.TOTP_PERIOD
+	for skew := -consts.TOTP_SKEW; skew <= consts.TOTP_SKEW; skew++ {
+		counter := current + uint64(skew)
+
+		expected, err := generators.GenerateTOTPCode(secret, counter)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter, true
+		}
+	}
+
+	return 0, false
+}